@@ -1,16 +1,21 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/anilsoylu/answer-backend/internal/database"
 	"github.com/anilsoylu/answer-backend/internal/database/seed"
 	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/anilsoylu/answer-backend/internal/jobs"
+	"github.com/anilsoylu/answer-backend/internal/models"
 	"github.com/anilsoylu/answer-backend/internal/routes"
 	"github.com/anilsoylu/answer-backend/internal/services"
 	"github.com/anilsoylu/answer-backend/pkg/middleware"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
@@ -42,19 +47,134 @@ func main() {
 
 	// Initialize services
 	authService := services.NewAuthService(database.DB())
+	retentionService := services.NewRetentionService(database.DB())
+	exportService := services.NewExportService(database.DB())
+	auditService := services.NewAuditService(database.DB())
+	bulkService := services.NewBulkService(database.DB(), authService, utils.GetEnvInt("BULK_SYNC_LIMIT", 100))
+	invitationService := services.NewInvitationService(database.DB())
+	approvalService := services.NewApprovalService(database.DB(), authService, bulkService, invitationService)
+	rootAdminService := services.NewRootAdminService(database.DB())
+	impersonationService := services.NewImpersonationService(database.DB())
+	inactivityService := services.NewInactivityService(database.DB())
+	warningService := services.NewWarningService(database.DB())
+	ipRuleService := services.NewIPRuleService(database.DB())
+	questionService := services.NewQuestionService(database.DB())
+	answerService := services.NewAnswerService(database.DB())
+	voteService := services.NewVoteService(database.DB())
+	reputationService := services.NewReputationService(database.DB())
+	privilegeService := services.NewPrivilegeService(database.DB())
+	tagService := services.NewTagService(database.DB())
+	tagSynonymService := services.NewTagSynonymService(database.DB())
+	commentService := services.NewCommentService(database.DB())
+	revisionService := services.NewRevisionService(database.DB())
+	suggestedEditService := services.NewSuggestedEditService(database.DB())
 
 	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(authService)
+	authHandler := handlers.NewAuthHandler(authService, approvalService)
+	adminHandler := handlers.NewAdminHandler(authService)
+	exportHandler := handlers.NewExportHandler(exportService)
+	bulkHandler := handlers.NewBulkHandler(bulkService, authService, approvalService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	approvalHandler := handlers.NewApprovalHandler(approvalService, authService)
+	rootAdminHandler := handlers.NewRootAdminHandler(rootAdminService)
+	invitationHandler := handlers.NewInvitationHandler(invitationService, approvalService, authService)
+	impersonationHandler := handlers.NewImpersonationHandler(impersonationService)
+	inactivityHandler := handlers.NewInactivityHandler(inactivityService)
+	warningHandler := handlers.NewWarningHandler(warningService)
+	ipRuleHandler := handlers.NewIPRuleHandler(ipRuleService)
+	questionHandler := handlers.NewQuestionHandler(questionService, voteService, commentService)
+	answerHandler := handlers.NewAnswerHandler(answerService, voteService, commentService)
+	voteHandler := handlers.NewVoteHandler(voteService)
+	reputationHandler := handlers.NewReputationHandler(reputationService)
+	privilegeHandler := handlers.NewPrivilegeHandler(privilegeService)
+	tagHandler := handlers.NewTagHandler(tagService)
+	tagSynonymHandler := handlers.NewTagSynonymHandler(tagSynonymService)
+	commentHandler := handlers.NewCommentHandler(commentService)
+	revisionHandler := handlers.NewRevisionHandler(revisionService)
+	suggestedEditHandler := handlers.NewSuggestedEditHandler(suggestedEditService)
+
+	// IP kuralları ilk istekten önce yüklenmeli
+	if err := ipRuleService.SyncRules(context.Background()); err != nil {
+		log.Fatal("Failed to load IP rules: ", err)
+	}
+
+	// Yeniden başlatmada yarıda kalan toplu işler başarısız olarak işaretlenir
+	if err := bulkService.FailInterruptedJobs(context.Background()); err != nil {
+		log.Printf("Failed to mark interrupted bulk jobs: %v", err)
+	}
+
+	// Start background jobs
+	scheduler := jobs.NewScheduler()
+	scheduler.Register(jobs.Job{
+		Name:     "account-anonymization",
+		Interval: utils.GetEnvDuration("ANONYMIZATION_JOB_INTERVAL", time.Hour),
+		Run:      retentionService.AnonymizeDeletedAccounts,
+	})
+	scheduler.Register(jobs.Job{
+		Name:     "data-export-cleanup",
+		Interval: utils.GetEnvDuration("EXPORT_CLEANUP_JOB_INTERVAL", time.Hour),
+		Run:      exportService.CleanupExports,
+	})
+	scheduler.Register(jobs.Job{
+		Name:     "approval-request-expiry",
+		Interval: utils.GetEnvDuration("APPROVAL_EXPIRY_JOB_INTERVAL", 15*time.Minute),
+		Run:      approvalService.ExpireStaleRequests,
+	})
+	scheduler.Register(jobs.Job{
+		Name:     "inactive-account-passivation",
+		Interval: utils.GetEnvDuration("INACTIVITY_JOB_INTERVAL", 24*time.Hour),
+		Run:      inactivityService.PassivateInactiveAccounts,
+	})
+	scheduler.Register(jobs.Job{
+		Name:     "ban-expiry",
+		Interval: utils.GetEnvDuration("BAN_EXPIRY_JOB_INTERVAL", 5*time.Minute),
+		Run:      authService.LiftExpiredBans,
+	})
+	scheduler.Register(jobs.Job{
+		Name:     "ip-rule-sync",
+		Interval: utils.GetEnvDuration("IP_RULE_SYNC_INTERVAL", time.Minute),
+		Run:      ipRuleService.SyncRules,
+	})
+	scheduler.Register(jobs.Job{
+		Name:     "tag-merge",
+		Interval: utils.GetEnvDuration("TAG_MERGE_JOB_INTERVAL", time.Minute),
+		Run:      tagSynonymService.ProcessPendingMerges,
+	})
+	scheduler.Start(context.Background())
 
 	// Initialize Gin router
 	router := gin.Default()
+	if err := middleware.ConfigureTrustedProxies(router); err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES: ", err)
+	}
 
 	// CORS middleware
 	router.Use(middleware.CORS())
+	router.Use(middleware.RequestID())
+	router.Use(middleware.IPFilter(models.IPRuleScopeAll))
+	middleware.SetIPBlocker(ipRuleService.IsBlocked)
+	middleware.SetImpersonationValidator(impersonationService.IsActive)
+	middleware.SetWarningChecker(warningService.HasUnacknowledgedWarnings)
+	middleware.SetPrivilegeChecker(privilegeService.Check)
 
 	// Setup routes
 	routes.SetupAuthRoutes(router, authHandler)
-	routes.SetupAdminRoutes(router, authHandler)
+	routes.SetupAdminRoutes(router, authHandler, adminHandler, bulkHandler, auditHandler, approvalHandler, rootAdminHandler)
+	routes.SetupExportRoutes(router, exportHandler)
+	routes.SetupInvitationRoutes(router, invitationHandler)
+	routes.SetupImpersonationRoutes(router, impersonationHandler)
+	routes.SetupInactivityRoutes(router, inactivityHandler)
+	routes.SetupWarningRoutes(router, warningHandler)
+	routes.SetupIPRuleRoutes(router, ipRuleHandler)
+	routes.SetupQuestionRoutes(router, questionHandler)
+	routes.SetupAnswerRoutes(router, answerHandler)
+	routes.SetupVoteRoutes(router, voteHandler)
+	routes.SetupReputationRoutes(router, reputationHandler, privilegeHandler)
+	routes.SetupTagRoutes(router, tagHandler)
+	routes.SetupTagSynonymRoutes(router, tagSynonymHandler)
+	routes.SetupCommentRoutes(router, commentHandler)
+	routes.SetupRevisionRoutes(router, revisionHandler)
+	routes.SetupSuggestedEditRoutes(router, suggestedEditHandler)
 
 	// Start server
 	port := os.Getenv("PORT")
@@ -0,0 +1,72 @@
+// Command rootadmin is the break-glass recovery tool for root admin access.
+// It talks to the database directly and is meant to be run by operators on the
+// server when no root admin can sign in anymore.
+//
+//	go run ./cmd/rootadmin -list
+//	go run ./cmd/rootadmin -grant <username or email> -reason "previous root admin left"
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/anilsoylu/answer-backend/internal/database"
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	list := flag.Bool("list", false, "list current root admins")
+	grant := flag.String("grant", "", "username or email of the account to make root admin")
+	reason := flag.String("reason", "", "why root access is being recovered, written to the audit log")
+	flag.Parse()
+
+	if !*list && *grant == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *grant != "" && *reason == "" {
+		log.Fatal("-reason is required when granting root admin status")
+	}
+
+	// .env yoksa ortam değişkenleri kullanılır
+	if err := godotenv.Load(); err != nil {
+		log.Printf("No .env file loaded: %v", err)
+	}
+
+	dbConfig := &database.DBConfig{
+		Host:     os.Getenv("DB_HOST"),
+		Port:     os.Getenv("DB_PORT"),
+		User:     os.Getenv("DB_USER"),
+		Password: os.Getenv("DB_PASSWORD"),
+		DBName:   os.Getenv("DB_NAME"),
+		SSLMode:  os.Getenv("DB_SSLMODE"),
+	}
+
+	if err := database.InitDB(dbConfig); err != nil {
+		log.Fatal("Could not initialize database: ", err)
+	}
+
+	rootAdminService := services.NewRootAdminService(database.DB())
+
+	if *grant != "" {
+		user, err := rootAdminService.BreakGlassGrant(context.Background(), *grant, *reason)
+		if err != nil {
+			log.Fatal("Failed to grant root admin status: ", err)
+		}
+		fmt.Printf("User %d (%s) is now a root admin\n", user.ID, user.Username)
+	}
+
+	if *list {
+		users, err := rootAdminService.ListRootAdmins()
+		if err != nil {
+			log.Fatal("Failed to list root admins: ", err)
+		}
+		for _, user := range users {
+			fmt.Printf("%d\t%s\t%s\t%s\n", user.ID, user.Username, user.Email, user.Status)
+		}
+	}
+}
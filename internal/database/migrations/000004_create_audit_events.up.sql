@@ -0,0 +1,13 @@
+-- Yönetici işlemlerinin kaydı
+CREATE TABLE IF NOT EXISTS audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id INTEGER REFERENCES users(id),
+    target_user_id INTEGER REFERENCES users(id),
+    action VARCHAR(100) NOT NULL,
+    details JSONB,
+    ip VARCHAR(45),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor ON audit_events (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_target ON audit_events (target_user_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS erasure_tombstones;
+
+ALTER TABLE users DROP COLUMN IF EXISTS anonymized_at;
@@ -0,0 +1,15 @@
+-- Silinen hesapların anonimleştirildiği zaman
+ALTER TABLE users ADD COLUMN IF NOT EXISTS anonymized_at TIMESTAMP;
+
+-- Kişisel verilerin silindiğini kanıtlayan kayıtlar (KVKK/GDPR)
+CREATE TABLE IF NOT EXISTS erasure_tombstones (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    deleted_at TIMESTAMP NOT NULL,
+    erased_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    erased_fields TEXT NOT NULL,
+    records_deleted JSONB,
+    reason VARCHAR(255) NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_erasure_tombstones_user ON erasure_tombstones (user_id);
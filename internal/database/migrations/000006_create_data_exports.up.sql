@@ -0,0 +1,14 @@
+-- Kişisel veri dışa aktarma talepleri (KVKK/GDPR veri taşınabilirliği)
+CREATE TABLE IF NOT EXISTS data_exports (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    token VARCHAR(64) NOT NULL UNIQUE,
+    file_path VARCHAR(255),
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    expires_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user ON data_exports (user_id);
@@ -0,0 +1,16 @@
+DROP TRIGGER IF EXISTS audit_events_no_truncate ON audit_events;
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+DROP FUNCTION IF EXISTS prevent_audit_event_changes();
+
+DROP INDEX IF EXISTS idx_audit_events_request;
+DROP INDEX IF EXISTS idx_audit_events_created_at;
+DROP INDEX IF EXISTS idx_audit_events_action;
+
+ALTER TABLE audit_events
+    DROP COLUMN IF EXISTS hash,
+    DROP COLUMN IF EXISTS prev_hash,
+    DROP COLUMN IF EXISTS request_id,
+    DROP COLUMN IF EXISTS after_state,
+    DROP COLUMN IF EXISTS before_state;
+
+ALTER TABLE audit_events ALTER COLUMN details TYPE JSONB USING details::jsonb;
@@ -0,0 +1,30 @@
+-- Hash zinciri için içerik birebir saklanmalı, JSONB anahtar sırasını ve boşlukları değiştirir
+ALTER TABLE audit_events ALTER COLUMN details TYPE TEXT USING details::text;
+
+ALTER TABLE audit_events
+    ADD COLUMN IF NOT EXISTS before_state TEXT,
+    ADD COLUMN IF NOT EXISTS after_state TEXT,
+    ADD COLUMN IF NOT EXISTS request_id VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS prev_hash CHAR(64),
+    ADD COLUMN IF NOT EXISTS hash CHAR(64);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_action ON audit_events (action);
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events (created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_request ON audit_events (request_id);
+
+-- Denetim kayıtları sadece eklenebilir
+CREATE OR REPLACE FUNCTION prevent_audit_event_changes() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION prevent_audit_event_changes();
+
+DROP TRIGGER IF EXISTS audit_events_no_truncate ON audit_events;
+CREATE TRIGGER audit_events_no_truncate
+    BEFORE TRUNCATE ON audit_events
+    FOR EACH STATEMENT EXECUTE FUNCTION prevent_audit_event_changes();
@@ -0,0 +1,19 @@
+-- İkinci bir yöneticinin onayını bekleyen hassas işlemler
+CREATE TABLE IF NOT EXISTS approval_requests (
+    id SERIAL PRIMARY KEY,
+    action VARCHAR(50) NOT NULL,
+    payload TEXT NOT NULL,
+    target_user_id INTEGER REFERENCES users(id),
+    requested_by INTEGER NOT NULL REFERENCES users(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    decided_by INTEGER REFERENCES users(id),
+    decided_at TIMESTAMP,
+    decision_reason TEXT,
+    execution_error TEXT,
+    result TEXT,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_approval_requests_status ON approval_requests (status);
+CREATE INDEX IF NOT EXISTS idx_approval_requests_target ON approval_requests (action, target_user_id) WHERE status = 'pending';
@@ -0,0 +1,12 @@
+-- Root admin işlemleri için e-posta ile gönderilen tek kullanımlık doğrulama kodları
+CREATE TABLE IF NOT EXISTS root_admin_challenges (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    code_hash CHAR(64) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_root_admin_challenges_user_id ON root_admin_challenges (user_id);
@@ -0,0 +1,18 @@
+-- Önceden rol atanmış, tek kullanımlık davetiyeler
+CREATE TABLE IF NOT EXISTS admin_invitations (
+    id SERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    role user_role NOT NULL,
+    nonce_hash CHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    invited_by INTEGER NOT NULL REFERENCES users(id),
+    accepted_user_id INTEGER REFERENCES users(id),
+    accepted_at TIMESTAMP,
+    revoked_by INTEGER REFERENCES users(id),
+    revoked_at TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_invitations_email ON admin_invitations (email);
+CREATE INDEX IF NOT EXISTS idx_admin_invitations_status ON admin_invitations (status);
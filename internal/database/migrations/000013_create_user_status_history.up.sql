@@ -0,0 +1,14 @@
+-- Hesap durumu geçişlerinin geçmişi
+CREATE TABLE IF NOT EXISTS user_status_history (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    event VARCHAR(30) NOT NULL,
+    from_status user_status NOT NULL,
+    to_status user_status NOT NULL,
+    triggered_by VARCHAR(20) NOT NULL,
+    actor_id INTEGER REFERENCES users(id),
+    reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_status_history_user_id ON user_status_history (user_id, created_at);
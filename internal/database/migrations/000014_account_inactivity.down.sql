@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_inactivity;
+DROP TABLE IF EXISTS account_reactivation_tokens;
+ALTER TABLE users DROP COLUMN IF EXISTS inactivity_warned_at;
@@ -0,0 +1,15 @@
+-- Uzun süre giriş yapmayan hesaplara gönderilen uyarının zamanı
+ALTER TABLE users ADD COLUMN IF NOT EXISTS inactivity_warned_at TIMESTAMP;
+
+-- Pasifleştirilen hesaplara e-posta ile gönderilen tek kullanımlık yeniden etkinleştirme bağlantıları
+CREATE TABLE IF NOT EXISTS account_reactivation_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_reactivation_tokens_user_id ON account_reactivation_tokens (user_id);
+CREATE INDEX IF NOT EXISTS idx_users_inactivity ON users (last_login_date) WHERE status = 'active' AND deleted_at IS NULL;
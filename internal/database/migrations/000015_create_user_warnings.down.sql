@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_users_ban_end_date;
+DROP TABLE IF EXISTS user_warnings;
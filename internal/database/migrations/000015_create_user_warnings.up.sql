@@ -0,0 +1,17 @@
+-- Moderatörlerin verdiği uyarılar, puanları belirli bir süre sonra düşer
+CREATE TABLE IF NOT EXISTS user_warnings (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    issued_by INTEGER NOT NULL REFERENCES users(id),
+    reason VARCHAR(50) NOT NULL,
+    note TEXT,
+    points INTEGER NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    acknowledged_at TIMESTAMP,
+    escalated_ban VARCHAR(20),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_warnings_user_id ON user_warnings (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_user_warnings_unacknowledged ON user_warnings (user_id) WHERE acknowledged_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_ban_end_date ON users (ban_end_date) WHERE status = 'banned';
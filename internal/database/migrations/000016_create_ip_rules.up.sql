@@ -0,0 +1,15 @@
+-- Yöneticilerin tanımladığı IP/CIDR engelleme ve izin kuralları
+CREATE TABLE IF NOT EXISTS ip_rules (
+    id SERIAL PRIMARY KEY,
+    cidr VARCHAR(50) NOT NULL,
+    action VARCHAR(10) NOT NULL CHECK (action IN ('block', 'allow')),
+    scope VARCHAR(20) NOT NULL CHECK (scope IN ('register', 'login', 'all')),
+    reason TEXT NOT NULL,
+    expires_at TIMESTAMP,
+    hit_count BIGINT NOT NULL DEFAULT 0,
+    last_hit_at TIMESTAMP,
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ip_rules_expires_at ON ip_rules (expires_at);
@@ -0,0 +1,15 @@
+-- Soru-cevap platformunun soruları, gövde markdown olarak saklanır
+CREATE TABLE IF NOT EXISTS questions (
+    id SERIAL PRIMARY KEY,
+    title VARCHAR(150) NOT NULL,
+    body TEXT NOT NULL,
+    author_id INTEGER NOT NULL REFERENCES users(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'closed')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_questions_author_id ON questions (author_id);
+CREATE INDEX IF NOT EXISTS idx_questions_created_at ON questions (created_at) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_questions_updated_at ON questions (updated_at) WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE questions DROP COLUMN IF EXISTS accepted_answer_id;
+DROP TABLE IF EXISTS answers;
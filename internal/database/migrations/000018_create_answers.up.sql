@@ -0,0 +1,17 @@
+-- Sorulara verilen cevaplar, gövde markdown olarak saklanır
+CREATE TABLE IF NOT EXISTS answers (
+    id SERIAL PRIMARY KEY,
+    question_id INTEGER NOT NULL REFERENCES questions(id),
+    author_id INTEGER NOT NULL REFERENCES users(id),
+    body TEXT NOT NULL,
+    score INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_answers_question_id ON answers (question_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_answers_author_id ON answers (author_id);
+
+-- Bir sorunun en fazla bir kabul edilmiş cevabı olabilir
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS accepted_answer_id INTEGER REFERENCES answers(id) ON DELETE SET NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE questions DROP COLUMN IF EXISTS score;
+DROP TABLE IF EXISTS votes;
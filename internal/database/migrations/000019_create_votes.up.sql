@@ -0,0 +1,16 @@
+-- Soru ve cevaplara verilen oylar, kullanıcı başına gönderi başına bir oy
+CREATE TABLE IF NOT EXISTS votes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    post_type VARCHAR(20) NOT NULL CHECK (post_type IN ('question', 'answer')),
+    post_id INTEGER NOT NULL,
+    value SMALLINT NOT NULL CHECK (value IN (-1, 1)),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, post_type, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_votes_post ON votes (post_type, post_id);
+
+-- Skor, oylar değiştiğinde aynı işlemde güncellenir
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS score INTEGER NOT NULL DEFAULT 0;
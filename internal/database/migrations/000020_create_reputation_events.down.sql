@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS reputation_events_append_only ON reputation_events;
+DROP FUNCTION IF EXISTS prevent_reputation_event_changes();
+DROP TABLE IF EXISTS reputation_events;
+ALTER TABLE users DROP COLUMN IF EXISTS reputation;
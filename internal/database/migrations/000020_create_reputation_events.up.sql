@@ -0,0 +1,31 @@
+-- Kullanıcıların önbelleğe alınmış itibar puanı, defterdeki değişikliklerin toplamıdır
+ALTER TABLE users ADD COLUMN IF NOT EXISTS reputation INTEGER NOT NULL DEFAULT 1;
+
+-- İtibar defteri, geri alınan kayıtlar silinmez, ters kayıt eklenir
+CREATE TABLE IF NOT EXISTS reputation_events (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    delta INTEGER NOT NULL,
+    reason VARCHAR(40) NOT NULL,
+    post_type VARCHAR(20),
+    post_id INTEGER,
+    vote_id INTEGER,
+    actor_id INTEGER REFERENCES users(id),
+    reversal_of INTEGER UNIQUE REFERENCES reputation_events(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_reputation_events_user_id ON reputation_events (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_reputation_events_vote_id ON reputation_events (vote_id) WHERE vote_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_reputation_events_post ON reputation_events (post_type, post_id);
+
+CREATE OR REPLACE FUNCTION prevent_reputation_event_changes() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'reputation_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS reputation_events_append_only ON reputation_events;
+CREATE TRIGGER reputation_events_append_only
+    BEFORE UPDATE OR DELETE ON reputation_events
+    FOR EACH ROW EXECUTE FUNCTION prevent_reputation_event_changes();
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_tag_preferences;
+DROP TABLE IF EXISTS question_tags;
+DROP TABLE IF EXISTS tags;
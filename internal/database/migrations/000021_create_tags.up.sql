@@ -0,0 +1,30 @@
+-- Soruları kategorize eden etiketler, soru sayısı etiketleme sırasında güncellenir
+CREATE TABLE IF NOT EXISTS tags (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(35) NOT NULL UNIQUE,
+    excerpt VARCHAR(500) NOT NULL DEFAULT '',
+    wiki TEXT NOT NULL DEFAULT '',
+    question_count INTEGER NOT NULL DEFAULT 0,
+    wiki_updated_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS question_tags (
+    question_id INTEGER NOT NULL REFERENCES questions(id),
+    tag_id INTEGER NOT NULL REFERENCES tags(id),
+    PRIMARY KEY (question_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_question_tags_tag_id ON question_tags (tag_id);
+
+-- Kullanıcıların takip ettiği veya yok saydığı etiketler
+CREATE TABLE IF NOT EXISTS user_tag_preferences (
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    tag_id INTEGER NOT NULL REFERENCES tags(id),
+    preference VARCHAR(10) NOT NULL CHECK (preference IN ('follow', 'ignore')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tag_preferences_tag_id ON user_tag_preferences (tag_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS tag_merges;
+DROP TABLE IF EXISTS tag_synonyms;
@@ -0,0 +1,36 @@
+-- Etiket eş anlamlıları, onaylanan eş anlamlılar etiketlemede hedef etikete çevrilir
+CREATE TABLE IF NOT EXISTS tag_synonyms (
+    id SERIAL PRIMARY KEY,
+    synonym VARCHAR(35) NOT NULL,
+    target_tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    proposed_by INTEGER NOT NULL REFERENCES users(id),
+    reviewed_by INTEGER REFERENCES users(id),
+    reject_reason VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reviewed_at TIMESTAMP
+);
+
+-- Bir isim yalnızca tek bir etiketin onaylı eş anlamlısı olabilir
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tag_synonyms_approved ON tag_synonyms (synonym) WHERE status = 'approved';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tag_synonyms_pending ON tag_synonyms (synonym, target_tag_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_tag_synonyms_target ON tag_synonyms (target_tag_id);
+
+-- Etiket birleştirmeleri arka planda soruları yeniden etiketler
+CREATE TABLE IF NOT EXISTS tag_merges (
+    id SERIAL PRIMARY KEY,
+    source_tag_id INTEGER REFERENCES tags(id) ON DELETE SET NULL,
+    source_name VARCHAR(35) NOT NULL,
+    target_tag_id INTEGER REFERENCES tags(id) ON DELETE SET NULL,
+    target_name VARCHAR(35) NOT NULL,
+    actor_id INTEGER NOT NULL REFERENCES users(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    total INTEGER NOT NULL DEFAULT 0,
+    processed INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_tag_merges_status ON tag_merges (status);
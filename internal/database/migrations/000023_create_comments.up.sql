@@ -0,0 +1,26 @@
+-- Soru ve cevaplara eklenen kısa yorumlar
+CREATE TABLE IF NOT EXISTS comments (
+    id SERIAL PRIMARY KEY,
+    post_type VARCHAR(20) NOT NULL CHECK (post_type IN ('question', 'answer')),
+    post_id INTEGER NOT NULL,
+    author_id INTEGER NOT NULL REFERENCES users(id),
+    body VARCHAR(600) NOT NULL,
+    score INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_comments_post ON comments (post_type, post_id, created_at) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_comments_author_id ON comments (author_id);
+CREATE INDEX IF NOT EXISTS idx_comments_deleted_at ON comments (deleted_at);
+
+-- Yorumlara yalnızca artı oy verilebilir, itibar etkisi yoktur
+CREATE TABLE IF NOT EXISTS comment_votes (
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    comment_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, comment_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_votes_comment_id ON comment_votes (comment_id);
@@ -0,0 +1,30 @@
+-- Soru ve cevapların her düzenlemesi tam içeriğiyle saklanır
+CREATE TABLE IF NOT EXISTS post_revisions (
+    id SERIAL PRIMARY KEY,
+    post_type VARCHAR(20) NOT NULL CHECK (post_type IN ('question', 'answer')),
+    post_id INTEGER NOT NULL,
+    revision INTEGER NOT NULL,
+    title VARCHAR(150) NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    tags VARCHAR(200) NOT NULL DEFAULT '',
+    editor_id INTEGER NOT NULL REFERENCES users(id),
+    summary VARCHAR(300) NOT NULL DEFAULT '',
+    rollback_of INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (post_type, post_id, revision)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_revisions_editor_id ON post_revisions (editor_id);
+
+-- Mevcut gönderiler için ilk revizyon bugünkü içerikten oluşturulur
+INSERT INTO post_revisions (post_type, post_id, revision, title, body, tags, editor_id, created_at)
+SELECT 'question', q.id, 1, q.title, q.body,
+    COALESCE((SELECT string_agg(t.name, ' ' ORDER BY t.name) FROM question_tags qt JOIN tags t ON t.id = qt.tag_id WHERE qt.question_id = q.id), ''),
+    q.author_id, q.created_at
+FROM questions q
+ON CONFLICT DO NOTHING;
+
+INSERT INTO post_revisions (post_type, post_id, revision, body, editor_id, created_at)
+SELECT 'answer', a.id, 1, a.body, a.author_id, a.created_at
+FROM answers a
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,23 @@
+-- Düzenleme yetkisi olmayan kullanıcıların önerdiği düzenlemeler inceleme kuyruğunda bekler
+CREATE TABLE IF NOT EXISTS suggested_edits (
+    id SERIAL PRIMARY KEY,
+    post_type VARCHAR(20) NOT NULL CHECK (post_type IN ('question', 'answer')),
+    post_id INTEGER NOT NULL,
+    base_revision INTEGER NOT NULL,
+    title VARCHAR(150) NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    tags VARCHAR(200) NOT NULL DEFAULT '',
+    summary VARCHAR(300) NOT NULL,
+    author_id INTEGER NOT NULL REFERENCES users(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'improved', 'rejected')),
+    reviewer_id INTEGER REFERENCES users(id),
+    reject_reason VARCHAR(300) NOT NULL DEFAULT '',
+    revision INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reviewed_at TIMESTAMP
+);
+
+-- Bir gönderi için aynı anda yalnızca bir öneri beklemede olabilir
+CREATE UNIQUE INDEX IF NOT EXISTS idx_suggested_edits_pending ON suggested_edits (post_type, post_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_suggested_edits_status ON suggested_edits (status, created_at);
+CREATE INDEX IF NOT EXISTS idx_suggested_edits_author_id ON suggested_edits (author_id);
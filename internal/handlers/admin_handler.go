@@ -2,19 +2,26 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/anilsoylu/answer-backend/internal/models"
 	"github.com/anilsoylu/answer-backend/internal/services"
 	"github.com/anilsoylu/answer-backend/pkg/utils"
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 )
 
 type AdminHandler struct {
 	authService *services.AuthService
+	validator   *validator.Validate
 }
 
 func NewAdminHandler(authService *services.AuthService) *AdminHandler {
 	return &AdminHandler{
 		authService: authService,
+		validator:   validator.New(),
 	}
 }
 
@@ -31,7 +38,7 @@ func (h *AdminHandler) Login(c *gin.Context) {
 		return
 	}
 
-	user, err := h.authService.AdminLogin(req.Identifier, req.Password)
+	user, err := h.authService.AdminLogin(req.Identifier, req.Password, c.ClientIP(), c.GetString("request_id"))
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"status": "error",
@@ -102,4 +109,432 @@ func (h *AdminHandler) Me(c *gin.Context) {
 			"user": user,
 		},
 	})
-} 
\ No newline at end of file
+} 
+type RestoreUserRequest struct {
+	Username string `json:"username" binding:"omitempty,min=3,max=50"`
+	Email    string `json:"email" binding:"omitempty,email"`
+}
+
+// adminUserResponse returns the full user record visible to admins
+func adminUserResponse(user *models.User) gin.H {
+	var deletedAt interface{}
+	if user.DeletedAt.Valid {
+		deletedAt = user.DeletedAt.Time
+	}
+
+	return gin.H{
+		"id":              user.ID,
+		"username":        user.Username,
+		"email":           user.Email,
+		"avatar":          user.Avatar,
+		"status":          user.Status,
+		"role":            user.Role,
+		"is_root_admin":   user.IsRootAdmin,
+		"created_at":      user.CreatedAt,
+		"last_login_date": user.LastLoginDate,
+		"ban_reason":      user.BanReason,
+		"ban_end_date":    user.BanEndDate,
+		"frozen_reason":   user.FrozenReason,
+		"frozen_date":     user.FrozenDate,
+		"deleted_at":      deletedAt,
+		"anonymized_at":   user.AnonymizedAt,
+	}
+}
+
+// ListDeletedUsers dondurulmuş ve silinmiş hesapları listeler
+func (h *AdminHandler) ListDeletedUsers(c *gin.Context) {
+	kind := c.Query("type")
+	if kind != "" && kind != services.DeletedKindFrozen && kind != services.DeletedKindDeleted {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "type must be one of: frozen deleted",
+			},
+		})
+		return
+	}
+
+	page, limit := parsePagination(c)
+	users, total, err := h.authService.ListDeletedUsers(kind, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "Failed to list deleted users",
+			},
+		})
+		return
+	}
+
+	items := make([]gin.H, 0, len(users))
+	for i := range users {
+		items = append(items, adminUserResponse(&users[i]))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"users": items,
+			"pagination": gin.H{
+				"page":  page,
+				"limit": limit,
+				"total": total,
+			},
+		},
+	})
+}
+
+// GetDeletedUser soft delete edilmiş bir hesabın detayını döner
+func (h *AdminHandler) GetDeletedUser(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid user ID",
+			},
+		})
+		return
+	}
+
+	user, err := h.authService.GetDeletedUser(uint(userID))
+	if err != nil {
+		switch err {
+		case services.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "not_found",
+					"message": "Deleted user not found",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to get deleted user",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"user": adminUserResponse(user),
+		},
+	})
+}
+
+// RestoreUser dondurulmuş veya silinmiş bir hesabı geri yükler
+func (h *AdminHandler) RestoreUser(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid user ID",
+			},
+		})
+		return
+	}
+
+	var req RestoreUserRequest
+	// Gövde opsiyoneldir, sadece çakışma durumunda yeni değerler gönderilir
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "validation_error",
+					"message": utils.GetValidationError(err),
+				},
+			})
+			return
+		}
+	}
+
+	user, err := h.authService.RestoreUser(c.Request.Context(), uint(userID), req.Username, req.Email, actorFromContext(c))
+	if err != nil {
+		switch err {
+		case services.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "not_found",
+					"message": "Deleted user not found",
+				},
+			})
+		case services.ErrForbidden:
+			c.JSON(http.StatusForbidden, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "forbidden",
+					"message": "You cannot restore this user",
+				},
+			})
+		case services.ErrUserAnonymized:
+			c.JSON(http.StatusGone, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "account_anonymized",
+					"message": "This account has been anonymized and cannot be restored",
+				},
+			})
+		case services.ErrUsernameTaken:
+			c.JSON(http.StatusConflict, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "username_taken",
+					"message": "Username is used by an active account, please provide a new username",
+				},
+			})
+		case services.ErrEmailTaken:
+			c.JSON(http.StatusConflict, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "email_taken",
+					"message": "Email is used by an active account, please provide a new email",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to restore user",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"user":    adminUserResponse(user),
+			"message": "User restored successfully",
+		},
+	})
+}
+
+// ListUsers filtreleme, arama ve cursor sayfalama ile kullanıcı dizinini döner
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	_, limit := parsePagination(c)
+	filter := services.UserListFilter{
+		Role:   models.UserRole(c.Query("role")),
+		Status: models.UserStatus(c.Query("status")),
+		Search: strings.TrimSpace(c.Query("q")),
+		Sort:   c.DefaultQuery("sort", "created_at"),
+		Order:  c.DefaultQuery("order", "desc"),
+		Cursor: c.Query("cursor"),
+		Limit:  limit,
+	}
+
+	if err := h.validator.Var(string(filter.Role), "omitempty,oneof=USER EDITOR ADMIN SUPER_ADMIN"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "role must be one of: USER EDITOR ADMIN SUPER_ADMIN",
+			},
+		})
+		return
+	}
+	if err := h.validator.Var(string(filter.Status), "omitempty,oneof=active passive banned frozen"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "status must be one of: active passive banned frozen",
+			},
+		})
+		return
+	}
+	if filter.Order != "asc" && filter.Order != "desc" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "order must be one of: asc desc",
+			},
+		})
+		return
+	}
+
+	ranges := []struct {
+		key    string
+		target **time.Time
+	}{
+		{"created_from", &filter.CreatedFrom},
+		{"created_to", &filter.CreatedTo},
+		{"last_login_from", &filter.LastLoginFrom},
+		{"last_login_to", &filter.LastLoginTo},
+		{"banned_until_from", &filter.BannedUntilFrom},
+		{"banned_until_to", &filter.BannedUntilTo},
+	}
+	for _, r := range ranges {
+		value, err := parseTimeQuery(c, r.key)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "validation_error",
+					"message": err.Error(),
+				},
+			})
+			return
+		}
+		*r.target = value
+	}
+
+	users, nextCursor, err := h.authService.ListUsers(filter)
+	if err != nil {
+		switch err {
+		case services.ErrInvalidSort:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "validation_error",
+					"message": "sort must be one of: id username created_at last_login_date",
+				},
+			})
+		case services.ErrInvalidCursor:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "invalid_cursor",
+					"message": "Invalid pagination cursor",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to list users",
+				},
+			})
+		}
+		return
+	}
+
+	items := make([]gin.H, 0, len(users))
+	for i := range users {
+		items = append(items, adminUserResponse(&users[i]))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"users": items,
+			"pagination": gin.H{
+				"limit":       limit,
+				"next_cursor": nextCursor,
+				"has_more":    nextCursor != "",
+			},
+		},
+	})
+}
+
+// GetUser kullanıcının ban ve dondurma alanları dahil tüm kaydını döner
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid user ID",
+			},
+		})
+		return
+	}
+
+	user, err := h.authService.GetUserDetail(uint(userID))
+	if err != nil {
+		switch err {
+		case services.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "not_found",
+					"message": "User not found",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to get user",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"user": adminUserResponse(user),
+		},
+	})
+}
+
+// GetStatusHistory kullanıcının hesap durumu geçişlerini listeler
+func (h *AdminHandler) GetStatusHistory(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid user ID",
+			},
+		})
+		return
+	}
+
+	page, limit := parsePagination(c)
+	history, total, err := h.authService.GetStatusHistory(uint(userID), page, limit)
+	if err != nil {
+		switch err {
+		case services.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "not_found",
+					"message": "User not found",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to get status history",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"history": history,
+			"pagination": gin.H{
+				"page":  page,
+				"limit": limit,
+				"total": total,
+			},
+		},
+	})
+}
@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type AnswerRequest struct {
+	Body string `json:"body" binding:"required,min=30,max=30000"`
+}
+
+type UpdateAnswerRequest struct {
+	Body    string `json:"body" binding:"required,min=30,max=30000"`
+	Summary string `json:"summary" binding:"max=300"`
+}
+
+type AnswerHandler struct {
+	answerService  *services.AnswerService
+	voteService    *services.VoteService
+	commentService *services.CommentService
+}
+
+func NewAnswerHandler(answerService *services.AnswerService, voteService *services.VoteService, commentService *services.CommentService) *AnswerHandler {
+	return &AnswerHandler{
+		answerService:  answerService,
+		voteService:    voteService,
+		commentService: commentService,
+	}
+}
+
+// List sorunun cevaplarını seçilen sıralamayla, en çok oy alan yorumlarıyla birlikte listeler
+func (h *AnswerHandler) List(c *gin.Context) {
+	questionID, ok := questionIDParam(c)
+	if !ok {
+		return
+	}
+
+	page, limit := parsePagination(c)
+	sort := c.DefaultQuery("sort", "votes")
+	if sort != "votes" && sort != "newest" && sort != "oldest" && sort != "active" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "sort must be one of: votes, newest, oldest, active",
+			},
+		})
+		return
+	}
+
+	answers, total, acceptedID, err := h.answerService.List(questionID, sort, page, limit)
+	if err != nil {
+		h.respondError(c, err, "Failed to list answers")
+		return
+	}
+
+	ids := make([]uint, 0, len(answers))
+	for _, answer := range answers {
+		ids = append(ids, answer.ID)
+	}
+	votes := loadPostVotes(c, h.voteService, nil, ids)
+	comments := loadTopComments(c, h.commentService, models.PostTypeAnswer, ids)
+
+	items := make([]gin.H, 0, len(answers))
+	for i := range answers {
+		items = append(items, withComments(answerResponse(&answers[i], acceptedID, votes), comments, answers[i].ID))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"answers":            items,
+			"accepted_answer_id": acceptedID,
+			"pagination": gin.H{
+				"page":  page,
+				"limit": limit,
+				"total": total,
+			},
+		},
+	})
+}
+
+// Create soruya giriş yapan kullanıcı adına cevap ekler
+func (h *AnswerHandler) Create(c *gin.Context) {
+	questionID, ok := questionIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req AnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	answer, err := h.answerService.Create(c.Request.Context(), questionID, req.Body, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to create answer")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"answer": answerResponse(answer, nil, postVotes{}),
+		},
+	})
+}
+
+// Update cevabı düzenler, yalnızca cevap sahibi ve yöneticiler düzenleyebilir
+func (h *AnswerHandler) Update(c *gin.Context) {
+	id, ok := answerIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	answer, acceptedID, err := h.answerService.Update(c.Request.Context(), id, req.Body, strings.TrimSpace(req.Summary), actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to update answer")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"answer": answerResponse(answer, acceptedID, loadPostVotes(c, h.voteService, nil, []uint{answer.ID})),
+		},
+	})
+}
+
+// Delete cevabı siler, kabul edilmiş cevapsa kabul de geri alınır
+func (h *AnswerHandler) Delete(c *gin.Context) {
+	id, ok := answerIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.answerService.Delete(c.Request.Context(), id, actorFromContext(c)); err != nil {
+		h.respondError(c, err, "Failed to delete answer")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Answer deleted successfully",
+		},
+	})
+}
+
+// Accept cevabı sorunun kabul edilmiş cevabı yapar, yalnızca soru sahibi kabul edebilir
+func (h *AnswerHandler) Accept(c *gin.Context) {
+	id, ok := answerIDParam(c)
+	if !ok {
+		return
+	}
+
+	answer, err := h.answerService.Accept(c.Request.Context(), id, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to accept answer")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"answer": answerResponse(answer, &answer.ID, loadPostVotes(c, h.voteService, nil, []uint{answer.ID})),
+		},
+	})
+}
+
+// Unaccept cevabın kabulünü geri alır
+func (h *AnswerHandler) Unaccept(c *gin.Context) {
+	id, ok := answerIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.answerService.Unaccept(c.Request.Context(), id, actorFromContext(c)); err != nil {
+		h.respondError(c, err, "Failed to unaccept answer")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Answer is no longer accepted",
+		},
+	})
+}
+
+func (h *AnswerHandler) respondError(c *gin.Context, err error, message string) {
+	if respondInsufficientReputation(c, err) {
+		return
+	}
+
+	switch err {
+	case services.ErrQuestionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Question not found",
+			},
+		})
+	case services.ErrAnswerNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Answer not found",
+			},
+		})
+	case services.ErrQuestionClosed:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "question_closed",
+				"message": "Closed questions cannot be answered",
+			},
+		})
+	case services.ErrAnswerNotAccepted:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_accepted",
+				"message": "This answer is not the accepted answer",
+			},
+		})
+	case services.ErrForbidden:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "forbidden",
+				"message": "You are not allowed to change this answer",
+			},
+		})
+	case services.ErrUserNotFound, services.ErrUserNotActive:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "user_not_active",
+				"message": "User account is not active",
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": message,
+			},
+		})
+	}
+}
+
+// answerIDParam reads the :id parameter and writes the error response when it is invalid
+func answerIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid answer ID",
+			},
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// answerResponse returns an answer with a public summary of its author.
+// acceptedID is the accepted answer of the question, if known.
+func answerResponse(answer *models.Answer, acceptedID *uint, votes postVotes) gin.H {
+	return gin.H{
+		"id":          answer.ID,
+		"question_id": answer.QuestionID,
+		"body":        answer.Body,
+		"score":       answer.Score,
+		"user_vote":   votes.answers[answer.ID],
+		"is_accepted": acceptedID != nil && *acceptedID == answer.ID,
+		"author":      authorSummary(&answer.Author),
+		"created_at":  answer.CreatedAt,
+		"updated_at":  answer.UpdatedAt,
+	}
+}
@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type RejectApprovalRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+type ApprovalHandler struct {
+	approvalService *services.ApprovalService
+	authService     *services.AuthService
+}
+
+func NewApprovalHandler(approvalService *services.ApprovalService, authService *services.AuthService) *ApprovalHandler {
+	return &ApprovalHandler{
+		approvalService: approvalService,
+		authService:     authService,
+	}
+}
+
+// respondApprovalRequired tells the client that the action waits for a second admin
+func respondApprovalRequired(c *gin.Context, approval *models.ApprovalRequest) {
+	c.JSON(http.StatusAccepted, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"approval_request": approval,
+			"message":          "This action requires approval by another admin",
+		},
+	})
+}
+
+func respondApprovalPending(c *gin.Context) {
+	c.JSON(http.StatusConflict, gin.H{
+		"status": "error",
+		"error": gin.H{
+			"code":    "approval_pending",
+			"message": "An approval request for this action is already pending",
+		},
+	})
+}
+
+// List onay isteklerini listeler
+func (h *ApprovalHandler) List(c *gin.Context) {
+	status := c.Query("status")
+	switch models.ApprovalStatus(status) {
+	case "", models.ApprovalPending, models.ApprovalApproved, models.ApprovalExecuted,
+		models.ApprovalFailed, models.ApprovalRejected, models.ApprovalExpired:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "status must be one of pending, approved, executed, failed, rejected, expired",
+			},
+		})
+		return
+	}
+
+	page, limit := parsePagination(c)
+
+	requests, total, err := h.approvalService.ListRequests(status, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "Failed to list approval requests",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"approval_requests": requests,
+			"pagination": gin.H{
+				"page":  page,
+				"limit": limit,
+				"total": total,
+			},
+		},
+	})
+}
+
+// Get tek bir onay isteğini döner
+func (h *ApprovalHandler) Get(c *gin.Context) {
+	id, ok := parseApprovalID(c)
+	if !ok {
+		return
+	}
+
+	request, err := h.approvalService.GetRequest(id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"approval_request": request,
+		},
+	})
+}
+
+// Approve isteği onaylar ve işlemi onaylayan yönetici adına çalıştırır
+func (h *ApprovalHandler) Approve(c *gin.Context) {
+	id, ok := parseApprovalID(c)
+	if !ok {
+		return
+	}
+
+	var approver models.User
+	if err := h.authService.GetUserByID(c.GetUint("user_id"), &approver); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "An error occurred while fetching user information",
+			},
+		})
+		return
+	}
+
+	actor := actorFromContext(c)
+	actor.IsRootAdmin = approver.IsRootAdmin
+
+	request, err := h.approvalService.Approve(c.Request.Context(), id, actor)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	if request.Status == models.ApprovalFailed {
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "execution_failed",
+				"message": "The request was approved but the action failed: " + request.ExecutionError,
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"approval_request": request,
+			"message":          "Request approved and executed successfully",
+		},
+	})
+}
+
+// Reject isteği reddeder, isteği açan yönetici de kendi isteğini reddederek iptal edebilir
+func (h *ApprovalHandler) Reject(c *gin.Context) {
+	id, ok := parseApprovalID(c)
+	if !ok {
+		return
+	}
+
+	var req RejectApprovalRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "validation_error",
+					"message": utils.GetValidationError(err),
+				},
+			})
+			return
+		}
+	}
+
+	request, err := h.approvalService.Reject(c.Request.Context(), id, req.Reason, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"approval_request": request,
+			"message":          "Request rejected successfully",
+		},
+	})
+}
+
+func (h *ApprovalHandler) respondError(c *gin.Context, err error) {
+	switch err {
+	case services.ErrApprovalNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Approval request not found",
+			},
+		})
+	case services.ErrApprovalNotPending:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_pending",
+				"message": "Approval request has already been decided",
+			},
+		})
+	case services.ErrApprovalExpired:
+		c.JSON(http.StatusGone, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "approval_expired",
+				"message": "Approval request has expired",
+			},
+		})
+	case services.ErrSelfApproval:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "self_approval",
+				"message": "Approval requests must be approved by a different admin",
+			},
+		})
+	case services.ErrForbidden:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "forbidden",
+				"message": "You are not allowed to approve this action",
+			},
+		})
+	case services.ErrUserNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "user_not_found",
+				"message": "User not found",
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "Failed to process approval request",
+			},
+		})
+	}
+}
+
+func parseApprovalID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid approval request ID",
+			},
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
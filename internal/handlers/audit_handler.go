@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	auditService *services.AuditService
+}
+
+func NewAuditHandler(auditService *services.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// List denetim kayıtlarını filtreleyerek listeler
+func (h *AuditHandler) List(c *gin.Context) {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	events, err := h.auditService.List(*filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "Failed to list audit events",
+			},
+		})
+		return
+	}
+
+	var nextCursor uint
+	if len(events) == filter.Limit {
+		nextCursor = events[len(events)-1].ID
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"events": events,
+			"pagination": gin.H{
+				"limit":       filter.Limit,
+				"next_cursor": nextCursor,
+			},
+		},
+	})
+}
+
+// Export denetim kayıtlarını CSV olarak dışa aktarır
+func (h *AuditHandler) Export(c *gin.Context) {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=audit-%s.csv", time.Now().Format("20060102-150405")))
+
+	writer := csv.NewWriter(c.Writer)
+	writer.Write([]string{"id", "created_at", "actor_id", "target_user_id", "action", "details", "before", "after", "ip", "request_id", "prev_hash", "hash"})
+
+	err = h.auditService.Export(c.Request.Context(), *filter, func(events []models.AuditEvent) error {
+		for _, event := range events {
+			writer.Write([]string{
+				strconv.FormatUint(uint64(event.ID), 10),
+				event.CreatedAt.Format(time.RFC3339Nano),
+				services.FormatOptionalID(event.ActorID),
+				services.FormatOptionalID(event.TargetUserID),
+				event.Action,
+				event.Details,
+				event.BeforeState,
+				event.AfterState,
+				event.IP,
+				event.RequestID,
+				event.PrevHash,
+				event.Hash,
+			})
+		}
+		writer.Flush()
+		return writer.Error()
+	})
+	if err != nil {
+		// Başlıklar gönderildiği için JSON hata dönülemez
+		c.Error(err)
+	}
+	writer.Flush()
+}
+
+// Verify hash zincirinin bütünlüğünü kontrol eder
+func (h *AuditHandler) Verify(c *gin.Context) {
+	result, err := h.auditService.Verify(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "Failed to verify audit chain",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"verification": result,
+		},
+	})
+}
+
+func parseAuditFilter(c *gin.Context) (*services.AuditFilter, error) {
+	_, limit := parsePagination(c)
+	filter := &services.AuditFilter{
+		Action:    c.Query("action"),
+		RequestID: c.Query("request_id"),
+		Limit:     limit,
+	}
+
+	ids := []struct {
+		key    string
+		target *uint
+	}{
+		{"actor_id", &filter.ActorID},
+		{"target_user_id", &filter.TargetUserID},
+		{"cursor", &filter.BeforeID},
+	}
+	for _, id := range ids {
+		value := c.Query(id.key)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a positive integer", id.key)
+		}
+		*id.target = uint(parsed)
+	}
+
+	var err error
+	if filter.From, err = parseTimeQuery(c, "from"); err != nil {
+		return nil, err
+	}
+	if filter.To, err = parseTimeQuery(c, "to"); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
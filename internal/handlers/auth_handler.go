@@ -24,6 +24,13 @@ type LoginRequest struct {
 	Password   string `json:"password" binding:"required"`
 }
 
+type ReactivateAccountRequest struct {
+	Identifier string `json:"identifier" binding:"required"`
+	Password   string `json:"password" binding:"required"`
+	Username   string `json:"username" binding:"omitempty,min=3,max=50"`
+	Email      string `json:"email" binding:"omitempty,email"`
+}
+
 type AuthResponse struct {
 	Token string `json:"token"`
 	User  struct {
@@ -41,14 +48,16 @@ type FreezeAccountRequest struct {
 }
 
 type AuthHandler struct {
-	authService *services.AuthService
-	validator   *validator.Validate
+	authService     *services.AuthService
+	approvalService *services.ApprovalService
+	validator       *validator.Validate
 }
 
-func NewAuthHandler(authService *services.AuthService) *AuthHandler {
+func NewAuthHandler(authService *services.AuthService, approvalService *services.ApprovalService) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
-		validator:   validator.New(),
+		authService:     authService,
+		approvalService: approvalService,
+		validator:       validator.New(),
 	}
 }
 
@@ -163,7 +172,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	user, err := h.authService.Login(req.Identifier, req.Password)
+	user, err := h.authService.Login(req.Identifier, req.Password, c.ClientIP(), c.GetString("request_id"))
 	if err != nil {
 		switch err {
 		case services.ErrInvalidCredentials:
@@ -182,6 +191,22 @@ func (h *AuthHandler) Login(c *gin.Context) {
 					"message": "User account is not active",
 				},
 			})
+		case services.ErrReactivationAvailable:
+			c.JSON(http.StatusForbidden, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "reactivation_available",
+					"message": "This account is frozen. Use /api/v1/auth/reactivate to reactivate it",
+				},
+			})
+		case services.ErrUserFrozen:
+			c.JSON(http.StatusForbidden, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "account_frozen",
+					"message": "This account is frozen, please contact support",
+				},
+			})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"status": "error",
@@ -223,6 +248,97 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	})
 }
 
+// ReactivateAccount dondurulmuş hesabı bekleme süresi içinde yeniden etkinleştirir
+func (h *AuthHandler) ReactivateAccount(c *gin.Context) {
+	var req ReactivateAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	user, err := h.authService.ReactivateAccount(req.Identifier, req.Password, req.Username, req.Email, c.ClientIP(), c.GetString("request_id"))
+	if err != nil {
+		switch err {
+		case services.ErrInvalidCredentials:
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "invalid_credentials",
+					"message": "Invalid username/email or password",
+				},
+			})
+		case services.ErrUserFrozen:
+			c.JSON(http.StatusForbidden, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "reactivation_expired",
+					"message": "The reactivation period for this account has expired, please contact support",
+				},
+			})
+		case services.ErrUsernameTaken:
+			c.JSON(http.StatusConflict, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "username_taken",
+					"message": "Username has been taken since the account was frozen, please provide a new username",
+				},
+			})
+		case services.ErrEmailTaken:
+			c.JSON(http.StatusConflict, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "email_taken",
+					"message": "Email has been taken since the account was frozen, please provide a new email",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to reactivate account",
+				},
+			})
+		}
+		return
+	}
+
+	token, err := utils.GenerateJWT(user.ID, user.Username, user.Email, user.Role, user.Status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "token_error",
+				"message": "Failed to generate token",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"token": token,
+			"user": gin.H{
+				"id":         user.ID,
+				"username":   user.Username,
+				"email":      user.Email,
+				"status":     user.Status,
+				"role":       user.Role,
+				"avatar":     user.Avatar,
+				"created_at": user.CreatedAt,
+			},
+			"message": "Account has been reactivated successfully",
+		},
+	})
+}
+
 func (h *AuthHandler) UpdateUserRole(c *gin.Context) {
 	var req models.UpdateUserRoleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -250,7 +366,28 @@ func (h *AuthHandler) UpdateUserRole(c *gin.Context) {
 		return
 	}
 
-	if err := h.authService.UpdateUserRole(req.UserID, req.Role, &requester); err != nil {
+	actor := actorFromContext(c)
+	actor.IsRootAdmin = requester.IsRootAdmin
+
+	// Admin rolleri ikinci bir yöneticinin onayıyla verilir
+	var err error
+	if h.approvalService.RoleChangeNeedsApproval(req.Role) {
+		var approval *models.ApprovalRequest
+		approval, err = h.approvalService.RequestRoleChange(c.Request.Context(), req.UserID, req.Role, actor)
+		if err == nil {
+			respondApprovalRequired(c, approval)
+			return
+		}
+	} else {
+		err = h.authService.UpdateUserRole(c.Request.Context(), req.UserID, req.Role, actor)
+	}
+
+	if err != nil {
+		if err == services.ErrApprovalPending {
+			respondApprovalPending(c)
+			return
+		}
+
 		switch err.Error() {
 		case "root admin's role cannot be changed":
 			c.JSON(http.StatusForbidden, gin.H{
@@ -343,7 +480,7 @@ func (h *AuthHandler) UpdateUserStatus(c *gin.Context) {
 		}
 	}
 
-	if err := h.authService.UpdateUserStatus(targetUserID, req.Status, requesterRole); err != nil {
+	if err := h.authService.UpdateUserStatus(c.Request.Context(), targetUserID, req.Status, actorFromContext(c)); err != nil {
 		switch err {
 		case services.ErrUserNotFound:
 			c.JSON(http.StatusNotFound, gin.H{
@@ -369,6 +506,14 @@ func (h *AuthHandler) UpdateUserStatus(c *gin.Context) {
 					"message": "You cannot change this user's status",
 				},
 			})
+		case services.ErrInvalidStatusTransition:
+			c.JSON(http.StatusConflict, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "invalid_status_transition",
+					"message": "This status change is not allowed from the user's current status",
+				},
+			})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"status": "error",
@@ -580,10 +725,7 @@ func (h *AuthHandler) BanUser(c *gin.Context) {
 		return
 	}
 
-	// İşlemi yapan kullanıcının rolünü al
-	requesterRole := models.UserRole(c.GetString("role"))
-
-	if err := h.authService.BanUser(c.Request.Context(), req.UserID, req.BanReason, req.BanDuration, requesterRole); err != nil {
+	if err := h.authService.BanUser(c.Request.Context(), req.UserID, req.BanReason, req.BanDuration, actorFromContext(c)); err != nil {
 		switch err {
 		case services.ErrUserNotFound:
 			c.JSON(http.StatusNotFound, gin.H{
@@ -609,6 +751,22 @@ func (h *AuthHandler) BanUser(c *gin.Context) {
 					"message": "You cannot ban this user",
 				},
 			})
+		case services.ErrInvalidStatusTransition:
+			c.JSON(http.StatusConflict, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "invalid_status_transition",
+					"message": "The user cannot be banned from the current status",
+				},
+			})
+		case services.ErrStatusReasonRequired:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "reason_required",
+					"message": "A reason is required for this status change",
+				},
+			})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"status": "error",
@@ -629,6 +787,85 @@ func (h *AuthHandler) BanUser(c *gin.Context) {
 	})
 }
 
+// UnbanUser kullanıcının banını kaldırır
+func (h *AuthHandler) UnbanUser(c *gin.Context) {
+	var req models.UnbanUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	if err := h.authService.UnbanUser(c.Request.Context(), req.UserID, actorFromContext(c)); err != nil {
+		switch err {
+		case services.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "not_found",
+					"message": "User not found",
+				},
+			})
+		case services.ErrUnauthorized:
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "unauthorized",
+					"message": "You are not authorized to unban users",
+				},
+			})
+		case services.ErrForbidden:
+			c.JSON(http.StatusForbidden, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "forbidden",
+					"message": "You cannot unban this user",
+				},
+			})
+		case services.ErrUserNotBanned, services.ErrInvalidStatusTransition:
+			c.JSON(http.StatusConflict, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "invalid_status_transition",
+					"message": "User is not banned",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to unban user",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "User unbanned successfully",
+		},
+	})
+}
+
 func (h *AuthHandler) FreezeAccount(c *gin.Context) {
 	var req FreezeAccountRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -642,9 +879,8 @@ func (h *AuthHandler) FreezeAccount(c *gin.Context) {
 		return
 	}
 
-	// Get requester's ID and role
+	// Get requester's ID
 	userID := c.GetUint("user_id")
-	requesterRole := c.GetString("role")
 
 	// Kullanıcı sadece kendi hesabını dondurabilir
 	if req.UserID != userID {
@@ -658,7 +894,7 @@ func (h *AuthHandler) FreezeAccount(c *gin.Context) {
 		return
 	}
 
-	if err := h.authService.FreezeAccount(c.Request.Context(), userID, req.FreezeReason, models.UserRole(requesterRole)); err != nil {
+	if err := h.authService.FreezeAccount(c.Request.Context(), userID, req.FreezeReason, actorFromContext(c)); err != nil {
 		switch err {
 		case services.ErrUserNotFound:
 			c.JSON(http.StatusNotFound, gin.H{
@@ -676,6 +912,22 @@ func (h *AuthHandler) FreezeAccount(c *gin.Context) {
 					"message": "You don't have permission to freeze this account",
 				},
 			})
+		case services.ErrInvalidStatusTransition:
+			c.JSON(http.StatusConflict, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "invalid_status_transition",
+					"message": "Only active or passive accounts can be frozen",
+				},
+			})
+		case services.ErrStatusReasonRequired:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "reason_required",
+					"message": "A reason is required for this status change",
+				},
+			})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"status": "error",
@@ -711,12 +963,24 @@ func (h *AuthHandler) DeleteAccount(c *gin.Context) {
 		return
 	}
 
-	// Get requester's ID and role
-	requesterID := c.GetUint("user_id")
-	requesterRole := models.UserRole(c.GetString("role"))
+	actor := actorFromContext(c)
+
+	// Başka bir kullanıcının hesabını silmek ikinci bir yöneticinin onayını gerektirir
+	if h.approvalService.DeletionNeedsApproval(uint(userID), actor) {
+		var approval *models.ApprovalRequest
+		approval, err = h.approvalService.RequestAccountDeletion(c.Request.Context(), uint(userID), actor)
+		if err == nil {
+			respondApprovalRequired(c, approval)
+			return
+		}
+	} else {
+		err = h.authService.DeleteAccount(c.Request.Context(), uint(userID), actor)
+	}
 
-	if err := h.authService.DeleteAccount(c.Request.Context(), uint(userID), requesterID, requesterRole); err != nil {
+	if err != nil {
 		switch err {
+		case services.ErrApprovalPending:
+			respondApprovalPending(c)
 		case services.ErrUserNotFound:
 			c.JSON(http.StatusNotFound, gin.H{
 				"status": "error",
@@ -741,6 +1005,14 @@ func (h *AuthHandler) DeleteAccount(c *gin.Context) {
 					"message": "SUPER_ADMIN accounts cannot be deleted",
 				},
 			})
+		case services.ErrInvalidStatusTransition:
+			c.JSON(http.StatusConflict, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "invalid_status_transition",
+					"message": "The account cannot be deleted from its current status",
+				},
+			})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"status": "error",
@@ -774,7 +1046,7 @@ func (h *AuthHandler) AdminLogin(c *gin.Context) {
 		return
 	}
 
-	user, err := h.authService.AdminLogin(req.Identifier, req.Password)
+	user, err := h.authService.AdminLogin(req.Identifier, req.Password, c.ClientIP(), c.GetString("request_id"))
 	if err != nil {
 		switch err {
 		case services.ErrInvalidCredentials:
@@ -856,18 +1128,30 @@ func (h *AuthHandler) Me(c *gin.Context) {
 		return
 	}
 
+	response := gin.H{
+		"id":         user.ID,
+		"username":   user.Username,
+		"email":      user.Email,
+		"status":     user.Status,
+		"role":       user.Role,
+		"avatar":     user.Avatar,
+		"created_at": user.CreatedAt,
+	}
+
+	// Kullanıcı adına açılmış oturumlarda istemci bunu açıkça göstermeli
+	if impersonatorID := c.GetUint("impersonator_id"); impersonatorID != 0 {
+		impersonatedBy := gin.H{"id": impersonatorID}
+		var impersonator models.User
+		if err := h.authService.GetUserByID(impersonatorID, &impersonator); err == nil {
+			impersonatedBy["username"] = impersonator.Username
+		}
+		response["impersonated_by"] = impersonatedBy
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
 		"data": gin.H{
-			"user": gin.H{
-				"id":         user.ID,
-				"username":   user.Username,
-				"email":      user.Email,
-				"status":     user.Status,
-				"role":       user.Role,
-				"avatar":     user.Avatar,
-				"created_at": user.CreatedAt,
-			},
+			"user": response,
 		},
 	})
 } 
\ No newline at end of file
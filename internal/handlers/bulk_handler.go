@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// BulkUserActionRequest is accepted as JSON or as a multipart form with a CSV file
+type BulkUserActionRequest struct {
+	Action      models.BulkAction `json:"action" form:"action" binding:"required,oneof=ban unban status role"`
+	UserIDs     []uint            `json:"user_ids" form:"-"`
+	BanReason   string            `json:"ban_reason" form:"ban_reason" binding:"required_if=Action ban,omitempty,min=10,max=500"`
+	BanDuration string            `json:"ban_duration" form:"ban_duration" binding:"required_if=Action ban,omitempty,oneof=1_day 1_week 1_month permanent"`
+	Status      models.UserStatus `json:"status" form:"status" binding:"required_if=Action status,omitempty,oneof=active passive"`
+	Role        models.UserRole   `json:"role" form:"role" binding:"required_if=Action role,omitempty,oneof=USER EDITOR ADMIN SUPER_ADMIN"`
+	DryRun      bool              `json:"dry_run" form:"dry_run"`
+}
+
+type BulkHandler struct {
+	bulkService     *services.BulkService
+	authService     *services.AuthService
+	approvalService *services.ApprovalService
+}
+
+func NewBulkHandler(bulkService *services.BulkService, authService *services.AuthService, approvalService *services.ApprovalService) *BulkHandler {
+	return &BulkHandler{
+		bulkService:     bulkService,
+		authService:     authService,
+		approvalService: approvalService,
+	}
+}
+
+// Start toplu ban, ban kaldırma, durum veya rol değişikliği başlatır
+func (h *BulkHandler) Start(c *gin.Context) {
+	var req BulkUserActionRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	// CSV yüklemesi varsa kullanıcı ID'leri dosyadan okunur
+	if file, err := c.FormFile("file"); err == nil {
+		f, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "invalid_file",
+					"message": "Could not read uploaded file",
+				},
+			})
+			return
+		}
+		defer f.Close()
+
+		ids, err := parseUserIDsCSV(f)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "invalid_file",
+					"message": err.Error(),
+				},
+			})
+			return
+		}
+		req.UserIDs = append(req.UserIDs, ids...)
+	}
+
+	var requester models.User
+	if err := h.authService.GetUserByID(c.GetUint("user_id"), &requester); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "An error occurred while fetching user information",
+			},
+		})
+		return
+	}
+
+	params := models.BulkActionParams{
+		BanReason:   req.BanReason,
+		BanDuration: req.BanDuration,
+		Status:      req.Status,
+		Role:        req.Role,
+	}
+
+	actor := actorFromContext(c)
+	actor.IsRootAdmin = requester.IsRootAdmin
+
+	// Toplu ban ve admin rolü ataması ikinci bir yöneticinin onayını gerektirir, dry run hariç
+	var job *models.BulkJob
+	var err error
+	if approvalAction := h.approvalService.BulkNeedsApproval(req.Action, params); approvalAction != "" && !req.DryRun {
+		var approval *models.ApprovalRequest
+		approval, err = h.approvalService.RequestBulkAction(c.Request.Context(), approvalAction, req.Action, params, req.UserIDs, actor)
+		if err == nil {
+			respondApprovalRequired(c, approval)
+			return
+		}
+	} else {
+		job, err = h.bulkService.Start(c.Request.Context(), actor, req.Action, params, req.UserIDs, req.DryRun)
+	}
+
+	if err != nil {
+		switch err {
+		case services.ErrBulkEmpty:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "validation_error",
+					"message": "user_ids or a CSV file with user IDs is required",
+				},
+			})
+		case services.ErrBulkTooLarge:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "too_many_users",
+					"message": fmt.Sprintf("A bulk action can contain at most %d users", services.MaxBulkRows),
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to start bulk action",
+				},
+			})
+		}
+		return
+	}
+
+	// Büyük işlemler arka planda çalışır, durum GET ile takip edilir
+	if job.Status != models.BulkJobCompleted {
+		c.JSON(http.StatusAccepted, gin.H{
+			"status": "success",
+			"data": gin.H{
+				"job":     job,
+				"message": "Bulk action is being processed in the background",
+			},
+		})
+		return
+	}
+
+	h.respondWithJob(c, job.ID)
+}
+
+// Get toplu işlemin durumunu ve satır bazlı sonuçlarını döner
+func (h *BulkHandler) Get(c *gin.Context) {
+	jobID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid job ID",
+			},
+		})
+		return
+	}
+
+	h.respondWithJob(c, uint(jobID))
+}
+
+func (h *BulkHandler) respondWithJob(c *gin.Context, jobID uint) {
+	job, results, err := h.bulkService.GetJob(jobID)
+	if err != nil {
+		switch err {
+		case services.ErrBulkJobNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "not_found",
+					"message": "Bulk job not found",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to get bulk job",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"job":     job,
+			"results": results,
+		},
+	})
+}
+
+// parseUserIDsCSV reads user IDs from a CSV file. The file either has a header row
+// with a user_id column or contains the IDs in its first column.
+func parseUserIDsCSV(r io.Reader) ([]uint, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV file: %v", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("CSV file is empty")
+	}
+
+	column := 0
+	if _, err := strconv.ParseUint(strings.TrimSpace(records[0][0]), 10, 32); err != nil {
+		column = -1
+		for i, name := range records[0] {
+			if strings.EqualFold(strings.TrimSpace(name), "user_id") {
+				column = i
+			}
+		}
+		if column == -1 {
+			return nil, errors.New("CSV file must have a user_id column")
+		}
+		records = records[1:]
+	}
+
+	ids := make([]uint, 0, len(records))
+	for i, record := range records {
+		if column >= len(record) || strings.TrimSpace(record[column]) == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSpace(record[column]), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user_id on row %d", i+1)
+		}
+		ids = append(ids, uint(id))
+	}
+
+	return ids, nil
+}
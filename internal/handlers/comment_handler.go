@@ -0,0 +1,358 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type CommentRequest struct {
+	Body string `json:"body" binding:"required,min=15,max=600"`
+}
+
+type CommentHandler struct {
+	commentService *services.CommentService
+}
+
+func NewCommentHandler(commentService *services.CommentService) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+	}
+}
+
+// List gönderinin yorumlarını yazılma sırasıyla sayfalı olarak listeler
+func (h *CommentHandler) List(postType models.PostType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postID, ok := postIDParam(c, postType)
+		if !ok {
+			return
+		}
+
+		page, limit := parsePagination(c)
+		comments, total, err := h.commentService.List(postType, postID, page, limit)
+		if err != nil {
+			h.respondError(c, err, "Failed to list comments")
+			return
+		}
+
+		upvotes := loadCommentUpvotes(c, h.commentService, comments)
+		items := make([]gin.H, 0, len(comments))
+		for i := range comments {
+			items = append(items, commentResponse(&comments[i], upvotes))
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "success",
+			"data": gin.H{
+				"comments": items,
+				"pagination": gin.H{
+					"page":  page,
+					"limit": limit,
+					"total": total,
+				},
+			},
+		})
+	}
+}
+
+// Create gönderiye giriş yapan kullanıcı adına yorum ekler
+func (h *CommentHandler) Create(postType models.PostType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postID, ok := postIDParam(c, postType)
+		if !ok {
+			return
+		}
+
+		var req CommentRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "validation_error",
+					"message": utils.GetValidationError(err),
+				},
+			})
+			return
+		}
+
+		comment, err := h.commentService.Create(c.Request.Context(), postType, postID, req.Body, actorFromContext(c))
+		if err != nil {
+			h.respondError(c, err, "Failed to create comment")
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"status": "success",
+			"data": gin.H{
+				"comment": commentResponse(comment, nil),
+			},
+		})
+	}
+}
+
+// Update yorumu düzenler, yalnızca yorum sahibi düzenleme süresi içinde düzenleyebilir
+func (h *CommentHandler) Update(c *gin.Context) {
+	id, ok := commentIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	comment, err := h.commentService.Update(c.Request.Context(), id, req.Body, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to update comment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"comment": commentResponse(comment, loadCommentUpvotes(c, h.commentService, []models.Comment{*comment})),
+		},
+	})
+}
+
+// Delete yorumu siler, yorum sahibi, editörler ve yöneticiler silebilir
+func (h *CommentHandler) Delete(c *gin.Context) {
+	id, ok := commentIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.commentService.Delete(c.Request.Context(), id, actorFromContext(c)); err != nil {
+		h.respondError(c, err, "Failed to delete comment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Comment deleted successfully",
+		},
+	})
+}
+
+// Upvote yoruma artı oy verir
+func (h *CommentHandler) Upvote(c *gin.Context) {
+	id, ok := commentIDParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.commentService.Upvote(c.Request.Context(), id, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to upvote comment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   result,
+	})
+}
+
+// RetractUpvote kullanıcının yoruma verdiği artı oyu geri alır
+func (h *CommentHandler) RetractUpvote(c *gin.Context) {
+	id, ok := commentIDParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.commentService.RetractUpvote(c.Request.Context(), id, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to retract comment upvote")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   result,
+	})
+}
+
+func (h *CommentHandler) respondError(c *gin.Context, err error, message string) {
+	if respondInsufficientReputation(c, err) {
+		return
+	}
+
+	switch err {
+	case services.ErrCommentNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Comment not found",
+			},
+		})
+	case services.ErrQuestionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Question not found",
+			},
+		})
+	case services.ErrAnswerNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Answer not found",
+			},
+		})
+	case services.ErrInvalidComment:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "Comment must be 15 to 600 characters",
+			},
+		})
+	case services.ErrCommentEditExpired:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "edit_window_expired",
+				"message": "Comments can only be edited shortly after they are posted",
+			},
+		})
+	case services.ErrSelfCommentUpvote:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "self_vote",
+				"message": "You cannot upvote your own comment",
+			},
+		})
+	case services.ErrForbidden:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "forbidden",
+				"message": "You can only change your own comments",
+			},
+		})
+	case services.ErrUserNotFound, services.ErrUserNotActive:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "user_not_active",
+				"message": "User account is not active",
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": message,
+			},
+		})
+	}
+}
+
+// commentIDParam reads the :id parameter and writes the error response when it is invalid
+func commentIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid comment ID",
+			},
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// commentResponse returns a comment with a public summary of its author.
+// upvotes holds the comments the current user has upvoted.
+func commentResponse(comment *models.Comment, upvotes map[uint]bool) gin.H {
+	return gin.H{
+		"id":         comment.ID,
+		"post_type":  comment.PostType,
+		"post_id":    comment.PostID,
+		"body":       comment.Body,
+		"score":      comment.Score,
+		"user_voted": upvotes[comment.ID],
+		"author":     authorSummary(&comment.Author),
+		"created_at": comment.CreatedAt,
+		"updated_at": comment.UpdatedAt,
+	}
+}
+
+// loadCommentUpvotes loads the upvotes of the current user on the given comments.
+// Like post votes, a failure is logged and the response is sent without them.
+func loadCommentUpvotes(c *gin.Context, commentService *services.CommentService, comments []models.Comment) map[uint]bool {
+	userID := c.GetUint("user_id")
+	if userID == 0 || len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+	upvotes, err := commentService.UserUpvotes(userID, ids)
+	if err != nil {
+		log.Printf("Failed to load comment upvotes of user %d: %v", userID, err)
+		return nil
+	}
+	return upvotes
+}
+
+// loadTopComments loads the inline comments of posts for a detail response. They only
+// decorate the response, so a failure is logged and the posts are sent without comments.
+func loadTopComments(c *gin.Context, commentService *services.CommentService, postType models.PostType, postIDs []uint) map[uint]gin.H {
+	top, err := commentService.TopComments(postType, postIDs)
+	if err != nil {
+		log.Printf("Failed to load comments of %s posts: %v", postType, err)
+		return nil
+	}
+
+	var all []models.Comment
+	for _, post := range top {
+		all = append(all, post.Comments...)
+	}
+	upvotes := loadCommentUpvotes(c, commentService, all)
+
+	result := make(map[uint]gin.H, len(top))
+	for postID, post := range top {
+		items := make([]gin.H, 0, len(post.Comments))
+		for i := range post.Comments {
+			items = append(items, commentResponse(&post.Comments[i], upvotes))
+		}
+		result[postID] = gin.H{
+			"comments":      items,
+			"comment_count": post.Total,
+		}
+	}
+	return result
+}
+
+// withComments adds the inline comments loaded by loadTopComments to a post response
+func withComments(post gin.H, comments map[uint]gin.H, postID uint) gin.H {
+	if inline, ok := comments[postID]; ok {
+		post["comments"] = inline["comments"]
+		post["comment_count"] = inline["comment_count"]
+	}
+	return post
+}
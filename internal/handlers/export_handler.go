@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type ExportHandler struct {
+	exportService *services.ExportService
+}
+
+func NewExportHandler(exportService *services.ExportService) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+	}
+}
+
+// RequestExport kişisel veri dışa aktarma talebi oluşturur
+func (h *ExportHandler) RequestExport(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	export, err := h.exportService.RequestExport(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case services.ErrExportInProgress:
+			c.JSON(http.StatusConflict, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "export_in_progress",
+					"message": "A data export is already being prepared",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to request data export",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"export":  export,
+			"message": "Your data export is being prepared, you will receive an email when it is ready",
+		},
+	})
+}
+
+// GetExport kullanıcının son dışa aktarma talebinin durumunu döner
+func (h *ExportHandler) GetExport(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	export, err := h.exportService.GetLatestExport(userID)
+	if err != nil {
+		switch err {
+		case services.ErrExportNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "not_found",
+					"message": "No data export found",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to get data export",
+				},
+			})
+		}
+		return
+	}
+
+	data := gin.H{
+		"export": export,
+	}
+	if export.Status == models.ExportStatusReady {
+		data["download_url"] = fmt.Sprintf("/api/v1/exports/%s", export.Token)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// Download süreli bağlantı ile dışa aktarma arşivini indirir
+func (h *ExportHandler) Download(c *gin.Context) {
+	export, err := h.exportService.GetDownload(c.Param("token"))
+	if err != nil {
+		switch err {
+		case services.ErrExportNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "not_found",
+					"message": "Data export not found",
+				},
+			})
+		case services.ErrExportExpired:
+			c.JSON(http.StatusGone, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "export_expired",
+					"message": "This download link has expired, please request a new export",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to download data export",
+				},
+			})
+		}
+		return
+	}
+
+	c.FileAttachment(export.FilePath, fmt.Sprintf("data-export-%d.zip", export.UserID))
+}
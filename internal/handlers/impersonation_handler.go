@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type StartImpersonationRequest struct {
+	UserID uint   `json:"user_id" binding:"required"`
+	Reason string `json:"reason" binding:"required,min=10,max=500"`
+}
+
+type ImpersonationHandler struct {
+	impersonationService *services.ImpersonationService
+}
+
+func NewImpersonationHandler(impersonationService *services.ImpersonationService) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		impersonationService: impersonationService,
+	}
+}
+
+// Start SUPER_ADMIN için kullanıcı adına kısıtlı bir oturum açar
+func (h *ImpersonationHandler) Start(c *gin.Context) {
+	var req StartImpersonationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	session, token, err := h.impersonationService.Start(c.Request.Context(), req.UserID, req.Reason, actorFromContext(c))
+	if err != nil {
+		switch err {
+		case services.ErrForbidden:
+			c.JSON(http.StatusForbidden, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "forbidden",
+					"message": "Only SUPER_ADMIN can impersonate users",
+				},
+			})
+		case services.ErrImpersonationNotAllowed:
+			c.JSON(http.StatusForbidden, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "impersonation_not_allowed",
+					"message": "Only USER and EDITOR accounts can be impersonated",
+				},
+			})
+		case services.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "user_not_found",
+					"message": "User not found",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to start impersonation",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"token":   token,
+			"session": session,
+		},
+	})
+}
+
+// Stop kullanıcı adına açılan oturumu sonlandırır, impersonation token ile çağrılır
+func (h *ImpersonationHandler) Stop(c *gin.Context) {
+	sessionID := c.GetUint("impersonation_session_id")
+	if sessionID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_impersonating",
+				"message": "This request was not made with an impersonation token",
+			},
+		})
+		return
+	}
+
+	if err := h.impersonationService.Stop(c.Request.Context(), sessionID, actorFromContext(c)); err != nil {
+		switch err {
+		case services.ErrImpersonationNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "not_found",
+					"message": "Impersonation session not found or already ended",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to stop impersonation",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Impersonation session ended",
+		},
+	})
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ReactivationLinkRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type InactivityHandler struct {
+	inactivityService *services.InactivityService
+}
+
+func NewInactivityHandler(inactivityService *services.InactivityService) *InactivityHandler {
+	return &InactivityHandler{
+		inactivityService: inactivityService,
+	}
+}
+
+// ReactivateWithLink hareketsizlik nedeniyle pasifleştirilen hesabı e-postadaki bağlantı ile etkinleştirir
+func (h *InactivityHandler) ReactivateWithLink(c *gin.Context) {
+	var req ReactivationLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	user, err := h.inactivityService.ReactivateWithLink(c.Request.Context(), req.Token, c.ClientIP(), c.GetString("request_id"))
+	if err != nil {
+		switch err {
+		case services.ErrReactivationLinkInvalid:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "invalid_reactivation_link",
+					"message": "Reactivation link is invalid, expired or has already been used",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to reactivate account",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"user": gin.H{
+				"id":       user.ID,
+				"username": user.Username,
+				"status":   user.Status,
+			},
+			"message": "Account has been reactivated, you can sign in now",
+		},
+	})
+}
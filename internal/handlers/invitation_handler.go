@@ -0,0 +1,349 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type CreateInvitationRequest struct {
+	Email string          `json:"email" binding:"required,email"`
+	Role  models.UserRole `json:"role" binding:"required,oneof=EDITOR ADMIN SUPER_ADMIN"`
+}
+
+// AcceptInvitationRequest accepts an invitation. Username is only needed when no
+// account exists for the invited email; password is either the new account's password
+// or the existing account's password.
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Username string `json:"username" binding:"omitempty,min=3,max=50"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+type InvitationHandler struct {
+	invitationService *services.InvitationService
+	approvalService   *services.ApprovalService
+	authService       *services.AuthService
+}
+
+func NewInvitationHandler(invitationService *services.InvitationService, approvalService *services.ApprovalService, authService *services.AuthService) *InvitationHandler {
+	return &InvitationHandler{
+		invitationService: invitationService,
+		approvalService:   approvalService,
+		authService:       authService,
+	}
+}
+
+// Create önceden rol atanmış bir davetiye oluşturur ve e-posta ile gönderir
+func (h *InvitationHandler) Create(c *gin.Context) {
+	var req CreateInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	var requester models.User
+	if err := h.authService.GetUserByID(c.GetUint("user_id"), &requester); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "An error occurred while fetching user information",
+			},
+		})
+		return
+	}
+
+	actor := actorFromContext(c)
+	actor.IsRootAdmin = requester.IsRootAdmin
+
+	// Admin rolü taşıyan davetiyeler ikinci bir yöneticinin onayıyla gönderilir
+	if h.approvalService.InvitationNeedsApproval(req.Role) {
+		approval, err := h.approvalService.RequestInvitation(c.Request.Context(), req.Email, req.Role, actor)
+		if err != nil {
+			h.respondError(c, err)
+			return
+		}
+		respondApprovalRequired(c, approval)
+		return
+	}
+
+	invitation, err := h.invitationService.Create(c.Request.Context(), req.Email, req.Role, actor)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"invitation": invitation,
+			"message":    "Invitation sent successfully",
+		},
+	})
+}
+
+// List davetiyeleri listeler
+func (h *InvitationHandler) List(c *gin.Context) {
+	status := c.Query("status")
+	switch models.InvitationStatus(status) {
+	case "", models.InvitationPending, models.InvitationAccepted, models.InvitationRevoked, models.InvitationExpired:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "status must be one of pending, accepted, revoked, expired",
+			},
+		})
+		return
+	}
+
+	page, limit := parsePagination(c)
+
+	invitations, total, err := h.invitationService.ListInvitations(status, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "Failed to list invitations",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"invitations": invitations,
+			"pagination": gin.H{
+				"page":  page,
+				"limit": limit,
+				"total": total,
+			},
+		},
+	})
+}
+
+// Revoke bekleyen bir davetiyeyi iptal eder
+func (h *InvitationHandler) Revoke(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid invitation ID",
+			},
+		})
+		return
+	}
+
+	var requester models.User
+	if err := h.authService.GetUserByID(c.GetUint("user_id"), &requester); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "An error occurred while fetching user information",
+			},
+		})
+		return
+	}
+
+	actor := actorFromContext(c)
+	actor.IsRootAdmin = requester.IsRootAdmin
+
+	invitation, err := h.invitationService.Revoke(c.Request.Context(), uint(id), actor)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"invitation": invitation,
+			"message":    "Invitation revoked successfully",
+		},
+	})
+}
+
+// Get davetiye bağlantısının geçerli olup olmadığını ve içeriğini döner
+func (h *InvitationHandler) Get(c *gin.Context) {
+	invitation, err := h.invitationService.GetByToken(c.Query("token"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"email":      invitation.Email,
+			"role":       invitation.Role,
+			"expires_at": invitation.ExpiresAt,
+		},
+	})
+}
+
+// Accept davetiyeyi kabul eder, hesap yoksa oluşturur, varsa rolünü yükseltir
+func (h *InvitationHandler) Accept(c *gin.Context) {
+	var req AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	user, err := h.invitationService.Accept(c.Request.Context(), req.Token, req.Username, req.Password, c.ClientIP(), c.GetString("request_id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	token, err := utils.GenerateJWT(user.ID, user.Username, user.Email, user.Role, user.Status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "token_error",
+				"message": "Failed to generate token",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"token": token,
+			"user": gin.H{
+				"id":         user.ID,
+				"username":   user.Username,
+				"email":      user.Email,
+				"status":     user.Status,
+				"role":       user.Role,
+				"avatar":     user.Avatar,
+				"created_at": user.CreatedAt,
+			},
+		},
+	})
+}
+
+func (h *InvitationHandler) respondError(c *gin.Context, err error) {
+	switch err {
+	case services.ErrInvitationNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Invitation not found",
+			},
+		})
+	case services.ErrInvitationInvalid:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_invitation",
+				"message": "Invitation link is invalid or has already been used",
+			},
+		})
+	case services.ErrInvitationExpired:
+		c.JSON(http.StatusGone, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invitation_expired",
+				"message": "Invitation has expired",
+			},
+		})
+	case services.ErrInvitationPending:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invitation_pending",
+				"message": "A pending invitation for this email already exists",
+			},
+		})
+	case services.ErrInvitationNotApplicable:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "role_not_higher",
+				"message": "Your account already has this role or a higher one",
+			},
+		})
+	case services.ErrInvitationSignup:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "username is required to create an account",
+			},
+		})
+	case services.ErrInvalidCredentials:
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_credentials",
+				"message": "Invalid password for the invited account",
+			},
+		})
+	case services.ErrUserNotActive:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "user_not_active",
+				"message": "The invited account is not active",
+			},
+		})
+	case services.ErrUsernameTaken:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "username_taken",
+				"message": "Username is already taken",
+			},
+		})
+	case services.ErrEmailTaken:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "email_taken",
+				"message": "Email is already taken",
+			},
+		})
+	case services.ErrForbidden:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "forbidden",
+				"message": "Only root admins can invite or revoke SUPER_ADMIN invitations",
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "Failed to process invitation",
+			},
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type CreateIPRuleRequest struct {
+	CIDR      string     `json:"cidr" binding:"required"`
+	Action    string     `json:"action" binding:"required,oneof=block allow"`
+	Scope     string     `json:"scope" binding:"required,oneof=register login all"`
+	Reason    string     `json:"reason" binding:"required,max=500"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type IPRuleHandler struct {
+	ipRuleService *services.IPRuleService
+}
+
+func NewIPRuleHandler(ipRuleService *services.IPRuleService) *IPRuleHandler {
+	return &IPRuleHandler{
+		ipRuleService: ipRuleService,
+	}
+}
+
+// Create yeni bir IP/CIDR engelleme veya izin kuralı ekler
+func (h *IPRuleHandler) Create(c *gin.Context) {
+	var req CreateIPRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "expires_at must be in the future",
+			},
+		})
+		return
+	}
+
+	rule, err := h.ipRuleService.Create(c.Request.Context(), req.CIDR, req.Action, req.Scope, req.Reason, req.ExpiresAt, actorFromContext(c))
+	if err != nil {
+		switch err {
+		case services.ErrInvalidCIDR:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "validation_error",
+					"message": "cidr must be an IP address or CIDR range",
+				},
+			})
+		case services.ErrIPRuleLockout:
+			c.JSON(http.StatusConflict, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "ip_rule_lockout",
+					"message": "This rule would block your own IP address",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to create IP rule",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"rule": rule,
+		},
+	})
+}
+
+// List IP kurallarını ve isabet sayılarını listeler
+func (h *IPRuleHandler) List(c *gin.Context) {
+	page, limit := parsePagination(c)
+	includeExpired := c.Query("include_expired") == "true"
+
+	rules, total, err := h.ipRuleService.List(includeExpired, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "Failed to list IP rules",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"rules": rules,
+			"pagination": gin.H{
+				"page":  page,
+				"limit": limit,
+				"total": total,
+			},
+		},
+	})
+}
+
+// Delete bir IP kuralını kaldırır
+func (h *IPRuleHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid IP rule ID",
+			},
+		})
+		return
+	}
+
+	if err := h.ipRuleService.Delete(c.Request.Context(), uint(id), actorFromContext(c)); err != nil {
+		switch err {
+		case services.ErrIPRuleNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "not_found",
+					"message": "IP rule not found",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to delete IP rule",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "IP rule deleted successfully",
+		},
+	})
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type PrivilegeHandler struct {
+	privilegeService *services.PrivilegeService
+}
+
+func NewPrivilegeHandler(privilegeService *services.PrivilegeService) *PrivilegeHandler {
+	return &PrivilegeHandler{
+		privilegeService: privilegeService,
+	}
+}
+
+// ForUser kullanıcının itibarla kazandığı ve sıradaki yetkileri listeler
+func (h *PrivilegeHandler) ForUser(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid user ID",
+			},
+		})
+		return
+	}
+
+	privileges, err := h.privilegeService.ForUser(uint(userID))
+	if err != nil {
+		switch err {
+		case services.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "not_found",
+					"message": "User not found",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to get privileges",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   privileges,
+	})
+}
+
+// respondInsufficientReputation writes the insufficient_reputation response when err is
+// an InsufficientReputationError, in the same format as middleware.RequirePrivilege
+func respondInsufficientReputation(c *gin.Context, err error) bool {
+	var insufficient *services.InsufficientReputationError
+	if !errors.As(err, &insufficient) {
+		return false
+	}
+
+	c.JSON(http.StatusForbidden, gin.H{
+		"status": "error",
+		"error": gin.H{
+			"code":                "insufficient_reputation",
+			"message":             fmt.Sprintf("You need %d reputation for this action", insufficient.Required),
+			"privilege":           insufficient.Privilege,
+			"required_reputation": insufficient.Required,
+		},
+	})
+	return true
+}
@@ -0,0 +1,417 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// questionExcerptLength is the number of characters of the body shown in listings
+const questionExcerptLength = 200
+
+type CreateQuestionRequest struct {
+	Title string   `json:"title" binding:"required,min=15,max=150"`
+	Body  string   `json:"body" binding:"required,min=30,max=30000"`
+	Tags  []string `json:"tags" binding:"required,min=1,max=5"`
+}
+
+type UpdateQuestionRequest struct {
+	Title   *string   `json:"title" binding:"omitempty,min=15,max=150"`
+	Body    *string   `json:"body" binding:"omitempty,min=30,max=30000"`
+	Tags    *[]string `json:"tags" binding:"omitempty,min=1,max=5"`
+	Status  *string   `json:"status" binding:"omitempty,oneof=open closed"`
+	Summary string    `json:"summary" binding:"max=300"`
+}
+
+type QuestionHandler struct {
+	questionService *services.QuestionService
+	voteService     *services.VoteService
+	commentService  *services.CommentService
+}
+
+func NewQuestionHandler(questionService *services.QuestionService, voteService *services.VoteService, commentService *services.CommentService) *QuestionHandler {
+	return &QuestionHandler{
+		questionService: questionService,
+		voteService:     voteService,
+		commentService:  commentService,
+	}
+}
+
+// List soruları sayfalı olarak listeler, giriş yapan kullanıcının yok saydığı etiketler gizlenir
+func (h *QuestionHandler) List(c *gin.Context) {
+	page, limit := parsePagination(c)
+
+	filter := services.QuestionListFilter{
+		Status:   models.QuestionStatus(c.Query("status")),
+		Search:   strings.TrimSpace(c.Query("search")),
+		Tag:      strings.TrimSpace(c.Query("tag")),
+		ViewerID: c.GetUint("user_id"),
+		Sort:     c.DefaultQuery("sort", "newest"),
+		Page:     page,
+		Limit:    limit,
+	}
+
+	switch c.Query("feed") {
+	case "":
+	case "following":
+		if filter.ViewerID == 0 {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "unauthorized",
+					"message": "Sign in to see questions of followed tags",
+				},
+			})
+			return
+		}
+		filter.Following = true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "feed must be: following",
+			},
+		})
+		return
+	}
+
+	if filter.Status != "" && filter.Status != models.QuestionOpen && filter.Status != models.QuestionClosed {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "status must be one of: open, closed",
+			},
+		})
+		return
+	}
+
+	if filter.Sort != "newest" && filter.Sort != "oldest" && filter.Sort != "active" && filter.Sort != "votes" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "sort must be one of: newest, oldest, active, votes",
+			},
+		})
+		return
+	}
+
+	if authorID := c.Query("author_id"); authorID != "" {
+		id, err := strconv.ParseUint(authorID, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "validation_error",
+					"message": "author_id must be a user ID",
+				},
+			})
+			return
+		}
+		filter.AuthorID = uint(id)
+	}
+
+	questions, total, err := h.questionService.List(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "Failed to list questions",
+			},
+		})
+		return
+	}
+
+	ids := make([]uint, 0, len(questions))
+	for _, question := range questions {
+		ids = append(ids, question.ID)
+	}
+	votes := loadPostVotes(c, h.voteService, ids, nil)
+
+	items := make([]gin.H, 0, len(questions))
+	for i := range questions {
+		items = append(items, questionSummaryResponse(&questions[i], votes))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"questions": items,
+			"pagination": gin.H{
+				"page":  page,
+				"limit": limit,
+				"total": total,
+			},
+		},
+	})
+}
+
+// Get tek bir sorunun detayını en çok oy alan yorumlarıyla birlikte döner
+func (h *QuestionHandler) Get(c *gin.Context) {
+	id, ok := questionIDParam(c)
+	if !ok {
+		return
+	}
+
+	question, err := h.questionService.Get(id)
+	if err != nil {
+		h.respondError(c, err, "Failed to get question")
+		return
+	}
+
+	response := questionResponse(question, h.questionVotes(c, question))
+	comments := loadTopComments(c, h.commentService, models.PostTypeQuestion, []uint{question.ID})
+	withComments(response, comments, question.ID)
+	if question.AcceptedAnswer != nil {
+		answerComments := loadTopComments(c, h.commentService, models.PostTypeAnswer, []uint{question.AcceptedAnswer.ID})
+		withComments(response["accepted_answer"].(gin.H), answerComments, question.AcceptedAnswer.ID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"question": response,
+		},
+	})
+}
+
+// Create giriş yapan kullanıcı adına yeni bir soru oluşturur
+func (h *QuestionHandler) Create(c *gin.Context) {
+	var req CreateQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	question, err := h.questionService.Create(c.Request.Context(), req.Title, req.Body, req.Tags, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to create question")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"question": questionResponse(question, postVotes{}),
+		},
+	})
+}
+
+// Update soruyu düzenler, yalnızca soru sahibi ve yöneticiler düzenleyebilir
+func (h *QuestionHandler) Update(c *gin.Context) {
+	id, ok := questionIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	update := services.QuestionUpdate{
+		Title:   req.Title,
+		Body:    req.Body,
+		Tags:    req.Tags,
+		Summary: strings.TrimSpace(req.Summary),
+	}
+	if req.Status != nil {
+		status := models.QuestionStatus(*req.Status)
+		update.Status = &status
+	}
+
+	question, err := h.questionService.Update(c.Request.Context(), id, update, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to update question")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"question": questionResponse(question, h.questionVotes(c, question)),
+		},
+	})
+}
+
+// Delete soruyu siler, yalnızca soru sahibi ve yöneticiler silebilir
+func (h *QuestionHandler) Delete(c *gin.Context) {
+	id, ok := questionIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.questionService.Delete(c.Request.Context(), id, actorFromContext(c)); err != nil {
+		h.respondError(c, err, "Failed to delete question")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Question deleted successfully",
+		},
+	})
+}
+
+// questionVotes loads the votes of the current user on a question and its accepted answer
+func (h *QuestionHandler) questionVotes(c *gin.Context, question *models.Question) postVotes {
+	var answerIDs []uint
+	if question.AcceptedAnswerID != nil {
+		answerIDs = append(answerIDs, *question.AcceptedAnswerID)
+	}
+	return loadPostVotes(c, h.voteService, []uint{question.ID}, answerIDs)
+}
+
+func (h *QuestionHandler) respondError(c *gin.Context, err error, message string) {
+	if respondInsufficientReputation(c, err) {
+		return
+	}
+
+	switch err {
+	case services.ErrQuestionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Question not found",
+			},
+		})
+	case services.ErrForbidden:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "forbidden",
+				"message": "You can only change your own questions",
+			},
+		})
+	case services.ErrInvalidTagName:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "Tags may only contain lowercase letters, digits and + # . - and must be at most 35 characters",
+			},
+		})
+	case services.ErrTagCount:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "A question needs 1 to 5 different tags",
+			},
+		})
+	case services.ErrUserNotFound, services.ErrUserNotActive:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "user_not_active",
+				"message": "User account is not active",
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": message,
+			},
+		})
+	}
+}
+
+// questionIDParam reads the :id parameter and writes the error response when it is invalid
+func questionIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid question ID",
+			},
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// questionResponse returns a question with a public summary of its author and the accepted answer
+func questionResponse(question *models.Question, votes postVotes) gin.H {
+	var accepted gin.H
+	if question.AcceptedAnswer != nil {
+		accepted = answerResponse(question.AcceptedAnswer, question.AcceptedAnswerID, votes)
+	}
+
+	return gin.H{
+		"id":                 question.ID,
+		"title":              question.Title,
+		"body":               question.Body,
+		"status":             question.Status,
+		"score":              question.Score,
+		"user_vote":          votes.questions[question.ID],
+		"tags":               tagNames(question.Tags),
+		"author":             authorSummary(&question.Author),
+		"accepted_answer_id": question.AcceptedAnswerID,
+		"accepted_answer":    accepted,
+		"created_at":         question.CreatedAt,
+		"updated_at":         question.UpdatedAt,
+	}
+}
+
+// questionSummaryResponse is the listing form of a question, the body is cut to an excerpt
+func questionSummaryResponse(question *models.Question, votes postVotes) gin.H {
+	return gin.H{
+		"id":                 question.ID,
+		"title":              question.Title,
+		"excerpt":            excerpt(question.Body, questionExcerptLength),
+		"status":             question.Status,
+		"score":              question.Score,
+		"user_vote":          votes.questions[question.ID],
+		"tags":               tagNames(question.Tags),
+		"author":             authorSummary(&question.Author),
+		"accepted_answer_id": question.AcceptedAnswerID,
+		"created_at":         question.CreatedAt,
+		"updated_at":         question.UpdatedAt,
+	}
+}
+
+// authorSummary is the public part of a user shown next to content
+func authorSummary(user *models.User) gin.H {
+	return gin.H{
+		"id":         user.ID,
+		"username":   user.Username,
+		"avatar":     user.Avatar,
+		"reputation": user.Reputation,
+	}
+}
+
+// excerpt cuts text to at most n characters without splitting a multi-byte character
+func excerpt(text string, n int) string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= n {
+		return string(runes)
+	}
+	return strings.TrimSpace(string(runes[:n])) + "…"
+}
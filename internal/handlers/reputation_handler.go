@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type ReputationHandler struct {
+	reputationService *services.ReputationService
+}
+
+func NewReputationHandler(reputationService *services.ReputationService) *ReputationHandler {
+	return &ReputationHandler{
+		reputationService: reputationService,
+	}
+}
+
+// History kullanıcının itibar puanını ve itibar defterini listeler
+func (h *ReputationHandler) History(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid user ID",
+			},
+		})
+		return
+	}
+
+	page, limit := parsePagination(c)
+	reputation, events, total, err := h.reputationService.History(uint(userID), page, limit)
+	if err != nil {
+		switch err {
+		case services.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "not_found",
+					"message": "User not found",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to get reputation history",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"reputation": reputation,
+			"events":     events,
+			"pagination": gin.H{
+				"page":  page,
+				"limit": limit,
+				"total": total,
+			},
+		},
+	})
+}
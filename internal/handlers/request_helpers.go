@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// actorFromContext builds the acting user from the values set by AuthMiddleware
+func actorFromContext(c *gin.Context) *services.Actor {
+	return &services.Actor{
+		ID:        c.GetUint("user_id"),
+		Role:      models.UserRole(c.GetString("role")),
+		IP:        c.ClientIP(),
+		RequestID: c.GetString("request_id"),
+
+		ImpersonatorID: c.GetUint("impersonator_id"),
+	}
+}
+
+// parsePagination reads page and limit query parameters with sane defaults
+func parsePagination(c *gin.Context) (int, int) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultPageLimit)))
+	if err != nil || limit < 1 {
+		limit = defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+
+	return page, limit
+}
+
+// parseTimeQuery parses an optional RFC3339 or YYYY-MM-DD query parameter
+func parseTimeQuery(c *gin.Context, key string) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC3339 timestamp or a YYYY-MM-DD date", key)
+	}
+	return &t, nil
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type RevisionHandler struct {
+	revisionService *services.RevisionService
+}
+
+func NewRevisionHandler(revisionService *services.RevisionService) *RevisionHandler {
+	return &RevisionHandler{
+		revisionService: revisionService,
+	}
+}
+
+// List gönderinin revizyonlarını en yeniden eskiye sayfalı olarak listeler
+func (h *RevisionHandler) List(postType models.PostType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postID, ok := postIDParam(c, postType)
+		if !ok {
+			return
+		}
+
+		page, limit := parsePagination(c)
+		revisions, total, err := h.revisionService.List(postType, postID, page, limit)
+		if err != nil {
+			h.respondError(c, err, "Failed to list revisions")
+			return
+		}
+
+		items := make([]gin.H, 0, len(revisions))
+		for i := range revisions {
+			items = append(items, revisionResponse(&revisions[i]))
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "success",
+			"data": gin.H{
+				"revisions": items,
+				"pagination": gin.H{
+					"page":  page,
+					"limit": limit,
+					"total": total,
+				},
+			},
+		})
+	}
+}
+
+// Get gönderinin tek bir revizyonunu getirir
+func (h *RevisionHandler) Get(postType models.PostType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postID, ok := postIDParam(c, postType)
+		if !ok {
+			return
+		}
+		revision, ok := revisionParam(c)
+		if !ok {
+			return
+		}
+
+		rev, err := h.revisionService.Get(postType, postID, revision)
+		if err != nil {
+			h.respondError(c, err, "Failed to get revision")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "success",
+			"data": gin.H{
+				"revision": revisionResponse(rev),
+			},
+		})
+	}
+}
+
+// Diff iki revizyon arasındaki farkı kelime düzeyinde döner
+func (h *RevisionHandler) Diff(postType models.PostType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postID, ok := postIDParam(c, postType)
+		if !ok {
+			return
+		}
+
+		from, errFrom := strconv.Atoi(c.Query("from"))
+		to, errTo := strconv.Atoi(c.Query("to"))
+		if errFrom != nil || errTo != nil || from < 1 || to < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "validation_error",
+					"message": "from and to must be revision numbers",
+				},
+			})
+			return
+		}
+
+		diff, err := h.revisionService.Diff(postType, postID, from, to)
+		if err != nil {
+			h.respondError(c, err, "Failed to compare revisions")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "success",
+			"data": gin.H{
+				"diff": diff,
+			},
+		})
+	}
+}
+
+// Rollback gönderiyi seçilen revizyona geri alır, gönderi sahibi, editörler ve yöneticiler geri alabilir
+func (h *RevisionHandler) Rollback(postType models.PostType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postID, ok := postIDParam(c, postType)
+		if !ok {
+			return
+		}
+		revision, ok := revisionParam(c)
+		if !ok {
+			return
+		}
+
+		rev, err := h.revisionService.Rollback(c.Request.Context(), postType, postID, revision, actorFromContext(c))
+		if err != nil {
+			h.respondError(c, err, "Failed to roll back post")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "success",
+			"data": gin.H{
+				"revision": revisionResponse(rev),
+			},
+		})
+	}
+}
+
+func (h *RevisionHandler) respondError(c *gin.Context, err error, message string) {
+	switch err {
+	case services.ErrRevisionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Revision not found",
+			},
+		})
+	case services.ErrQuestionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Question not found",
+			},
+		})
+	case services.ErrAnswerNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Answer not found",
+			},
+		})
+	case services.ErrRevisionIsCurrent:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "revision_is_current",
+				"message": "The post already has the content of this revision",
+			},
+		})
+	case services.ErrInvalidTagName, services.ErrTagCount:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_tag",
+				"message": "The tags of this revision are no longer valid",
+			},
+		})
+	case services.ErrForbidden:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "forbidden",
+				"message": "Only the author, editors and admins can roll back a post",
+			},
+		})
+	case services.ErrUserNotFound, services.ErrUserNotActive:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "user_not_active",
+				"message": "User account is not active",
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": message,
+			},
+		})
+	}
+}
+
+// revisionParam reads the :revision parameter and writes the error response when it is invalid
+func revisionParam(c *gin.Context) (int, bool) {
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil || revision < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid revision number",
+			},
+		})
+		return 0, false
+	}
+	return revision, true
+}
+
+// revisionResponse returns a revision with a public summary of its editor. Title and
+// tags are only part of question revisions.
+func revisionResponse(rev *models.PostRevision) gin.H {
+	response := gin.H{
+		"revision":    rev.Revision,
+		"post_type":   rev.PostType,
+		"post_id":     rev.PostID,
+		"body":        rev.Body,
+		"summary":     rev.Summary,
+		"rollback_of": rev.RollbackOf,
+		"editor":      authorSummary(&rev.Editor),
+		"created_at":  rev.CreatedAt,
+	}
+	if rev.PostType == models.PostTypeQuestion {
+		response["title"] = rev.Title
+		response["tags"] = rev.TagNames()
+	}
+	return response
+}
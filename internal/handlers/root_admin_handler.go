@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// RootAdminChangeRequest confirms a root status change with the acting root admin's
+// password and the emailed verification code
+type RootAdminChangeRequest struct {
+	UserID   uint   `json:"user_id" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required,numeric,min=6,max=6"`
+}
+
+type RootAdminHandler struct {
+	rootAdminService *services.RootAdminService
+}
+
+func NewRootAdminHandler(rootAdminService *services.RootAdminService) *RootAdminHandler {
+	return &RootAdminHandler{
+		rootAdminService: rootAdminService,
+	}
+}
+
+// List root admin yetkisine sahip kullanıcıları döner
+func (h *RootAdminHandler) List(c *gin.Context) {
+	users, err := h.rootAdminService.ListRootAdmins()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "Failed to list root admins",
+			},
+		})
+		return
+	}
+
+	response := make([]gin.H, 0, len(users))
+	for i := range users {
+		response = append(response, gin.H{
+			"id":       users[i].ID,
+			"username": users[i].Username,
+			"email":    users[i].Email,
+			"role":     users[i].Role,
+			"status":   users[i].Status,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"root_admins": response,
+		},
+	})
+}
+
+// SendVerificationCode root admin'in e-posta adresine tek kullanımlık kod gönderir
+func (h *RootAdminHandler) SendVerificationCode(c *gin.Context) {
+	challenge, err := h.rootAdminService.SendVerificationCode(c.Request.Context(), actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"expires_at": challenge.ExpiresAt,
+			"message":    "A verification code has been sent to your email address",
+		},
+	})
+}
+
+// Grant başka bir SUPER_ADMIN'e root admin yetkisi verir
+func (h *RootAdminHandler) Grant(c *gin.Context) {
+	h.change(c, h.rootAdminService.Grant, "Root admin status granted successfully")
+}
+
+// Transfer root admin yetkisini başka bir SUPER_ADMIN'e devreder
+func (h *RootAdminHandler) Transfer(c *gin.Context) {
+	h.change(c, h.rootAdminService.Transfer, "Root admin status transferred successfully")
+}
+
+// Revoke root admin yetkisini kaldırır, son root admin'in yetkisi kaldırılamaz
+func (h *RootAdminHandler) Revoke(c *gin.Context) {
+	h.change(c, h.rootAdminService.Revoke, "Root admin status revoked successfully")
+}
+
+func (h *RootAdminHandler) change(c *gin.Context, apply func(ctx context.Context, targetID uint, password, code string, actor *services.Actor) error, message string) {
+	var req RootAdminChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	if err := apply(c.Request.Context(), req.UserID, req.Password, req.Code, actorFromContext(c)); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": message,
+		},
+	})
+}
+
+func (h *RootAdminHandler) respondError(c *gin.Context, err error) {
+	switch err {
+	case services.ErrNotRootAdmin:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "forbidden",
+				"message": "Only root admins can manage root admin status",
+			},
+		})
+	case services.ErrInvalidVerification:
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_verification",
+				"message": "Invalid password or verification code",
+			},
+		})
+	case services.ErrUserNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "user_not_found",
+				"message": "User not found",
+			},
+		})
+	case services.ErrLastRootAdmin:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "last_root_admin",
+				"message": "The last root admin cannot be removed",
+			},
+		})
+	case services.ErrAlreadyRootAdmin:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "already_root_admin",
+				"message": "User is already a root admin",
+			},
+		})
+	case services.ErrTargetNotRootAdmin:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_root_admin",
+				"message": "User is not a root admin",
+			},
+		})
+	case services.ErrRootAdminTarget:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_target",
+				"message": "Root admin status can only be given to active SUPER_ADMIN accounts",
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "Failed to change root admin status",
+			},
+		})
+	}
+}
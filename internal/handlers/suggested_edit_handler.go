@@ -0,0 +1,414 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type SuggestEditRequest struct {
+	Title   *string   `json:"title" binding:"omitempty,min=15,max=150"`
+	Body    *string   `json:"body" binding:"omitempty,min=30,max=30000"`
+	Tags    *[]string `json:"tags" binding:"omitempty,min=1,max=5"`
+	Summary string    `json:"summary" binding:"required,max=300"`
+}
+
+type RejectSuggestedEditRequest struct {
+	Reason string `json:"reason" binding:"required,min=10,max=300"`
+}
+
+type SuggestedEditHandler struct {
+	suggestedEditService *services.SuggestedEditService
+}
+
+func NewSuggestedEditHandler(suggestedEditService *services.SuggestedEditService) *SuggestedEditHandler {
+	return &SuggestedEditHandler{
+		suggestedEditService: suggestedEditService,
+	}
+}
+
+// Create başka bir kullanıcının gönderisi için düzenleme önerir
+func (h *SuggestedEditHandler) Create(postType models.PostType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postID, ok := postIDParam(c, postType)
+		if !ok {
+			return
+		}
+
+		req, ok := bindSuggestEditRequest(c)
+		if !ok {
+			return
+		}
+
+		edit, err := h.suggestedEditService.Suggest(c.Request.Context(), postType, postID, req.input(), actorFromContext(c))
+		if err != nil {
+			h.respondError(c, err, "Failed to suggest edit")
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"status": "success",
+			"data": gin.H{
+				"suggested_edit": suggestedEditResponse(edit),
+			},
+		})
+	}
+}
+
+// List inceleme kuyruğundaki önerileri listeler, editörler, yöneticiler ve edit_posts yetkisi olanlar görebilir
+func (h *SuggestedEditHandler) List(c *gin.Context) {
+	h.list(c, 0)
+}
+
+// ListMine giriş yapan kullanıcının önerdiği düzenlemeleri listeler
+func (h *SuggestedEditHandler) ListMine(c *gin.Context) {
+	h.list(c, c.GetUint("user_id"))
+}
+
+func (h *SuggestedEditHandler) list(c *gin.Context, authorID uint) {
+	page, limit := parsePagination(c)
+	defaultStatus := string(models.SuggestedEditPending)
+	if authorID != 0 {
+		// Kullanıcı kendi önerilerinin tamamını görür
+		defaultStatus = ""
+	}
+	status := models.SuggestedEditStatus(c.DefaultQuery("status", defaultStatus))
+
+	switch status {
+	case "", models.SuggestedEditPending, models.SuggestedEditApproved, models.SuggestedEditImproved, models.SuggestedEditRejected:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "status must be one of: pending, approved, improved, rejected",
+			},
+		})
+		return
+	}
+
+	filter := services.SuggestedEditFilter{
+		Status:   status,
+		AuthorID: authorID,
+		Page:     page,
+		Limit:    limit,
+	}
+	edits, total, err := h.suggestedEditService.List(filter, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to list suggested edits")
+		return
+	}
+
+	items := make([]gin.H, 0, len(edits))
+	for i := range edits {
+		items = append(items, suggestedEditResponse(&edits[i]))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"suggested_edits": items,
+			"pagination": gin.H{
+				"page":  page,
+				"limit": limit,
+				"total": total,
+			},
+		},
+	})
+}
+
+// Get öneriyi dayandığı revizyona göre kelime düzeyindeki farkıyla birlikte getirir
+func (h *SuggestedEditHandler) Get(c *gin.Context) {
+	id, ok := suggestedEditIDParam(c)
+	if !ok {
+		return
+	}
+
+	edit, err := h.suggestedEditService.Get(id)
+	if err != nil {
+		h.respondError(c, err, "Failed to get suggested edit")
+		return
+	}
+
+	response := suggestedEditResponse(edit)
+	// Fark yalnızca yanıtı zenginleştirir, hata loglanır ve öneri farksız döner
+	if diff, err := h.suggestedEditService.Diff(edit); err != nil {
+		log.Printf("Failed to compare suggested edit %d: %v", edit.ID, err)
+	} else {
+		response["diff"] = diff
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"suggested_edit": response,
+		},
+	})
+}
+
+// Approve öneriyi onaylar ve gönderiye yeni revizyon olarak uygular
+func (h *SuggestedEditHandler) Approve(c *gin.Context) {
+	id, ok := suggestedEditIDParam(c)
+	if !ok {
+		return
+	}
+
+	edit, err := h.suggestedEditService.Approve(c.Request.Context(), id, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to approve suggested edit")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"suggested_edit": suggestedEditResponse(edit),
+		},
+	})
+}
+
+// Improve öneriyi onaylar ve inceleyenin düzeltmelerini üzerine ayrı bir revizyon olarak uygular
+func (h *SuggestedEditHandler) Improve(c *gin.Context) {
+	id, ok := suggestedEditIDParam(c)
+	if !ok {
+		return
+	}
+
+	req, ok := bindSuggestEditRequest(c)
+	if !ok {
+		return
+	}
+
+	edit, err := h.suggestedEditService.Improve(c.Request.Context(), id, req.input(), actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to improve suggested edit")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"suggested_edit": suggestedEditResponse(edit),
+		},
+	})
+}
+
+// Reject öneriyi gerekçesiyle birlikte reddeder
+func (h *SuggestedEditHandler) Reject(c *gin.Context) {
+	id, ok := suggestedEditIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req RejectSuggestedEditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	edit, err := h.suggestedEditService.Reject(c.Request.Context(), id, req.Reason, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to reject suggested edit")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"suggested_edit": suggestedEditResponse(edit),
+		},
+	})
+}
+
+func (h *SuggestedEditHandler) respondError(c *gin.Context, err error, message string) {
+	if respondInsufficientReputation(c, err) {
+		return
+	}
+
+	switch err {
+	case services.ErrSuggestedEditNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Suggested edit not found",
+			},
+		})
+	case services.ErrQuestionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Question not found",
+			},
+		})
+	case services.ErrAnswerNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Answer not found",
+			},
+		})
+	case services.ErrInvalidTagName:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "Tags may only contain lowercase letters, digits and + # . - and must be at most 35 characters",
+			},
+		})
+	case services.ErrTagCount:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "A question needs 1 to 5 different tags",
+			},
+		})
+	case services.ErrSuggestedEditUnchanged:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "no_changes",
+				"message": "The suggested edit does not change the post",
+			},
+		})
+	case services.ErrCanEditDirectly:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "can_edit_directly",
+				"message": "You can edit this post directly",
+			},
+		})
+	case services.ErrSuggestedEditPending:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "edit_pending",
+				"message": "This post already has a suggested edit waiting for review",
+			},
+		})
+	case services.ErrSuggestedEditReviewed:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "already_reviewed",
+				"message": "Suggested edit is already reviewed",
+			},
+		})
+	case services.ErrSuggestedEditOutdated:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "edit_outdated",
+				"message": "The post was edited after the suggestion, it can only be rejected",
+			},
+		})
+	case services.ErrForbidden:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "forbidden",
+				"message": "You cannot review your own suggested edit",
+			},
+		})
+	case services.ErrUserNotFound, services.ErrUserNotActive:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "user_not_active",
+				"message": "User account is not active",
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": message,
+			},
+		})
+	}
+}
+
+func (r *SuggestEditRequest) input() services.SuggestedEditInput {
+	return services.SuggestedEditInput{
+		Title:   r.Title,
+		Body:    r.Body,
+		Tags:    r.Tags,
+		Summary: r.Summary,
+	}
+}
+
+// bindSuggestEditRequest reads the proposed content and writes the error response when it is invalid
+func bindSuggestEditRequest(c *gin.Context) (*SuggestEditRequest, bool) {
+	var req SuggestEditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return nil, false
+	}
+	return &req, true
+}
+
+// suggestedEditIDParam reads the :id parameter and writes the error response when it is invalid
+func suggestedEditIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid suggested edit ID",
+			},
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// suggestedEditResponse returns a suggested edit with public summaries of its author
+// and reviewer. Title and tags are only part of suggestions for questions.
+func suggestedEditResponse(edit *models.SuggestedEdit) gin.H {
+	response := gin.H{
+		"id":            edit.ID,
+		"post_type":     edit.PostType,
+		"post_id":       edit.PostID,
+		"base_revision": edit.BaseRevision,
+		"body":          edit.Body,
+		"summary":       edit.Summary,
+		"status":        edit.Status,
+		"author":        authorSummary(&edit.Author),
+		"reviewer":      nil,
+		"reject_reason": edit.RejectReason,
+		"revision":      edit.Revision,
+		"created_at":    edit.CreatedAt,
+		"reviewed_at":   edit.ReviewedAt,
+	}
+	if edit.PostType == models.PostTypeQuestion {
+		response["title"] = edit.Title
+		response["tags"] = edit.TagNames()
+	}
+	if edit.Reviewer != nil {
+		response["reviewer"] = authorSummary(edit.Reviewer)
+	}
+	return response
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateTagWikiRequest struct {
+	Excerpt *string `json:"excerpt" binding:"omitempty,max=500"`
+	Wiki    *string `json:"wiki" binding:"omitempty,max=30000"`
+}
+
+type TagPreferenceRequest struct {
+	Preference string `json:"preference" binding:"required,oneof=follow ignore"`
+}
+
+type TagHandler struct {
+	tagService *services.TagService
+}
+
+func NewTagHandler(tagService *services.TagService) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+	}
+}
+
+// List etiketleri sayfalı olarak listeler
+func (h *TagHandler) List(c *gin.Context) {
+	page, limit := parsePagination(c)
+	sort := c.DefaultQuery("sort", "popular")
+
+	if sort != "popular" && sort != "name" && sort != "newest" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "sort must be one of: popular, name, newest",
+			},
+		})
+		return
+	}
+
+	tags, total, err := h.tagService.List(c.Query("search"), sort, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "Failed to list tags",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"tags": tags,
+			"pagination": gin.H{
+				"page":  page,
+				"limit": limit,
+				"total": total,
+			},
+		},
+	})
+}
+
+// Get etiketin açıklamasını ve wiki metnini döner
+func (h *TagHandler) Get(c *gin.Context) {
+	tag, err := h.tagService.Get(c.Param("name"))
+	if err != nil {
+		h.respondError(c, err, "Failed to get tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"tag": tag,
+		},
+	})
+}
+
+// UpdateWiki etiketin açıklamasını düzenler, yalnızca editörler ve yöneticiler düzenleyebilir
+func (h *TagHandler) UpdateWiki(c *gin.Context) {
+	var req UpdateTagWikiRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	update := services.TagWikiUpdate{
+		Excerpt: req.Excerpt,
+		Wiki:    req.Wiki,
+	}
+	tag, err := h.tagService.UpdateWiki(c.Request.Context(), c.Param("name"), update, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to update tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"tag": tag,
+		},
+	})
+}
+
+// SetPreference giriş yapan kullanıcının etiketi takip etmesini veya yok saymasını sağlar
+func (h *TagHandler) SetPreference(c *gin.Context) {
+	var req TagPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	pref, err := h.tagService.SetPreference(c.Request.Context(), c.GetUint("user_id"), c.Param("name"), models.TagPreference(req.Preference))
+	if err != nil {
+		h.respondError(c, err, "Failed to save tag preference")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"preference": pref,
+		},
+	})
+}
+
+// ClearPreference etiketin takibini veya yok sayılmasını kaldırır
+func (h *TagHandler) ClearPreference(c *gin.Context) {
+	if err := h.tagService.ClearPreference(c.Request.Context(), c.GetUint("user_id"), c.Param("name")); err != nil {
+		h.respondError(c, err, "Failed to remove tag preference")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"message": "Tag preference removed successfully",
+		},
+	})
+}
+
+// ListPreferences giriş yapan kullanıcının takip ettiği ve yok saydığı etiketleri listeler
+func (h *TagHandler) ListPreferences(c *gin.Context) {
+	prefs, err := h.tagService.Preferences(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "Failed to list tag preferences",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"preferences": prefs,
+		},
+	})
+}
+
+func (h *TagHandler) respondError(c *gin.Context, err error, message string) {
+	switch err {
+	case services.ErrTagNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Tag not found",
+			},
+		})
+	case services.ErrForbidden:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "forbidden",
+				"message": "Only editors can edit tag wikis",
+			},
+		})
+	case services.ErrUserNotFound, services.ErrUserNotActive:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "user_not_active",
+				"message": "User account is not active",
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": message,
+			},
+		})
+	}
+}
+
+// tagNames returns the names of tags in their stored order
+func tagNames(tags []models.Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
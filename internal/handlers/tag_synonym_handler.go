@@ -0,0 +1,341 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ProposeTagSynonymRequest struct {
+	Synonym string `json:"synonym" binding:"required,max=35"`
+}
+
+type RejectTagSynonymRequest struct {
+	Reason string `json:"reason" binding:"required,min=10,max=500"`
+}
+
+type TagMergeRequest struct {
+	Source string `json:"source" binding:"required,max=35"`
+	Target string `json:"target" binding:"required,max=35"`
+}
+
+type TagSynonymHandler struct {
+	tagSynonymService *services.TagSynonymService
+}
+
+func NewTagSynonymHandler(tagSynonymService *services.TagSynonymService) *TagSynonymHandler {
+	return &TagSynonymHandler{
+		tagSynonymService: tagSynonymService,
+	}
+}
+
+// ListForTag etiketin onaylı ve bekleyen eş anlamlılarını listeler
+func (h *TagSynonymHandler) ListForTag(c *gin.Context) {
+	synonyms, err := h.tagSynonymService.ListForTag(c.Param("name"))
+	if err != nil {
+		h.respondError(c, err, "Failed to list tag synonyms")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"synonyms": synonyms,
+		},
+	})
+}
+
+// Propose etiket için yeni bir eş anlamlı önerir
+func (h *TagSynonymHandler) Propose(c *gin.Context) {
+	var req ProposeTagSynonymRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	synonym, err := h.tagSynonymService.Propose(c.Request.Context(), c.Param("name"), req.Synonym, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to propose tag synonym")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"synonym": synonym,
+		},
+	})
+}
+
+// List eş anlamlı önerilerini inceleme için listeler, yalnızca editörler ve yöneticiler görebilir
+func (h *TagSynonymHandler) List(c *gin.Context) {
+	page, limit := parsePagination(c)
+	status := models.TagSynonymStatus(c.DefaultQuery("status", string(models.TagSynonymPending)))
+
+	if status != models.TagSynonymPending && status != models.TagSynonymApproved && status != models.TagSynonymRejected {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "status must be one of: pending, approved, rejected",
+			},
+		})
+		return
+	}
+
+	synonyms, total, err := h.tagSynonymService.List(status, page, limit, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to list tag synonyms")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"synonyms": synonyms,
+			"pagination": gin.H{
+				"page":  page,
+				"limit": limit,
+				"total": total,
+			},
+		},
+	})
+}
+
+// Approve eş anlamlı önerisini onaylar
+func (h *TagSynonymHandler) Approve(c *gin.Context) {
+	id, ok := tagSynonymIDParam(c)
+	if !ok {
+		return
+	}
+
+	synonym, err := h.tagSynonymService.Approve(c.Request.Context(), id, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to approve tag synonym")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"synonym": synonym,
+		},
+	})
+}
+
+// Reject eş anlamlı önerisini gerekçesiyle reddeder
+func (h *TagSynonymHandler) Reject(c *gin.Context) {
+	id, ok := tagSynonymIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req RejectTagSynonymRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	synonym, err := h.tagSynonymService.Reject(c.Request.Context(), id, req.Reason, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to reject tag synonym")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"synonym": synonym,
+		},
+	})
+}
+
+// StartMerge kaynak etiketi hedef etikete birleştirir, sorular arka planda yeniden etiketlenir
+func (h *TagSynonymHandler) StartMerge(c *gin.Context) {
+	var req TagMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	merge, err := h.tagSynonymService.StartMerge(c.Request.Context(), req.Source, req.Target, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to start tag merge")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"merge":   merge,
+			"message": "Tag merge is being processed in the background",
+		},
+	})
+}
+
+// GetMerge etiket birleştirmesinin durumunu döner
+func (h *TagSynonymHandler) GetMerge(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid merge ID",
+			},
+		})
+		return
+	}
+
+	merge, err := h.tagSynonymService.GetMerge(uint(id))
+	if err != nil {
+		h.respondError(c, err, "Failed to get tag merge")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"merge": merge,
+		},
+	})
+}
+
+func (h *TagSynonymHandler) respondError(c *gin.Context, err error, message string) {
+	switch err {
+	case services.ErrTagNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Tag not found",
+			},
+		})
+	case services.ErrTagSynonymNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Tag synonym not found",
+			},
+		})
+	case services.ErrTagMergeNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Tag merge not found",
+			},
+		})
+	case services.ErrInvalidTagName:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "Tags may only contain lowercase letters, digits and + # . - and must be at most 35 characters",
+			},
+		})
+	case services.ErrTagSynonymSelf:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": "A tag cannot be a synonym of itself",
+			},
+		})
+	case services.ErrTagSynonymExists:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "synonym_exists",
+				"message": "This synonym is already approved or proposed",
+			},
+		})
+	case services.ErrTagIsSynonym:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "tag_is_synonym",
+				"message": "The tag is a synonym of another tag",
+			},
+		})
+	case services.ErrTagSynonymReviewed:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "already_reviewed",
+				"message": "This synonym proposal is already reviewed",
+			},
+		})
+	case services.ErrTagMergeInProgress:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "merge_in_progress",
+				"message": "One of the tags is part of a merge in progress",
+			},
+		})
+	case services.ErrForbidden:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "forbidden",
+				"message": "Only editors can review tag synonyms",
+			},
+		})
+	case services.ErrUserNotFound, services.ErrUserNotActive:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "user_not_active",
+				"message": "User account is not active",
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": message,
+			},
+		})
+	}
+}
+
+// tagSynonymIDParam reads the :id parameter and writes the error response when it is invalid
+func tagSynonymIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid synonym ID",
+			},
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
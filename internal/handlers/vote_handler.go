@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type VoteHandler struct {
+	voteService *services.VoteService
+}
+
+func NewVoteHandler(voteService *services.VoteService) *VoteHandler {
+	return &VoteHandler{
+		voteService: voteService,
+	}
+}
+
+// Upvote gönderiye artı oy verir
+func (h *VoteHandler) Upvote(postType models.PostType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h.vote(c, postType, models.VoteUp)
+	}
+}
+
+// Downvote gönderiye eksi oy verir
+func (h *VoteHandler) Downvote(postType models.PostType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h.vote(c, postType, models.VoteDown)
+	}
+}
+
+// Retract kullanıcının gönderiye verdiği oyu geri alır
+func (h *VoteHandler) Retract(postType models.PostType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postID, ok := postIDParam(c, postType)
+		if !ok {
+			return
+		}
+
+		result, err := h.voteService.Retract(c.Request.Context(), postType, postID, actorFromContext(c))
+		if err != nil {
+			h.respondError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "success",
+			"data":   result,
+		})
+	}
+}
+
+func (h *VoteHandler) vote(c *gin.Context, postType models.PostType, value int) {
+	postID, ok := postIDParam(c, postType)
+	if !ok {
+		return
+	}
+
+	result, err := h.voteService.Vote(c.Request.Context(), postType, postID, value, actorFromContext(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   result,
+	})
+}
+
+func (h *VoteHandler) respondError(c *gin.Context, err error) {
+	switch err {
+	case services.ErrQuestionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Question not found",
+			},
+		})
+	case services.ErrAnswerNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "not_found",
+				"message": "Answer not found",
+			},
+		})
+	case services.ErrSelfVote:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "self_vote",
+				"message": "You cannot vote on your own post",
+			},
+		})
+	case services.ErrUserNotFound, services.ErrUserNotActive:
+		c.JSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "user_not_active",
+				"message": "User account is not active",
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "internal_error",
+				"message": "Failed to process vote",
+			},
+		})
+	}
+}
+
+// postIDParam reads the :id parameter of a question or answer route
+func postIDParam(c *gin.Context, postType models.PostType) (uint, bool) {
+	if postType == models.PostTypeAnswer {
+		return answerIDParam(c)
+	}
+	return questionIDParam(c)
+}
+
+// postVotes holds the votes of the current user on the posts of a response.
+// Anonymous users have no votes; missing entries are returned as 0.
+type postVotes struct {
+	questions map[uint]int
+	answers   map[uint]int
+}
+
+// loadPostVotes loads the votes of the current user on the given posts. The votes
+// only decorate the response, so a failure is logged and the response is sent without them.
+func loadPostVotes(c *gin.Context, voteService *services.VoteService, questionIDs, answerIDs []uint) postVotes {
+	var votes postVotes
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		return votes
+	}
+
+	var err error
+	if votes.questions, err = voteService.UserVotes(userID, models.PostTypeQuestion, questionIDs); err != nil {
+		log.Printf("Failed to load question votes of user %d: %v", userID, err)
+	}
+	if votes.answers, err = voteService.UserVotes(userID, models.PostTypeAnswer, answerIDs); err != nil {
+		log.Printf("Failed to load answer votes of user %d: %v", userID, err)
+	}
+	return votes
+}
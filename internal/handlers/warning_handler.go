@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/anilsoylu/answer-backend/internal/services"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type IssueWarningRequest struct {
+	Reason string `json:"reason" binding:"required"`
+	Note   string `json:"note" binding:"max=1000"`
+}
+
+type WarningHandler struct {
+	warningService *services.WarningService
+}
+
+func NewWarningHandler(warningService *services.WarningService) *WarningHandler {
+	return &WarningHandler{
+		warningService: warningService,
+	}
+}
+
+// Reasons uyarılarda seçilebilecek hazır sebepleri döner
+func (h *WarningHandler) Reasons(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"reasons": h.warningService.Reasons(),
+		},
+	})
+}
+
+// Issue kullanıcıya uyarı verir, puan eşiği aşılırsa kullanıcı otomatik banlanır
+func (h *WarningHandler) Issue(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid user ID",
+			},
+		})
+		return
+	}
+
+	var req IssueWarningRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "validation_error",
+				"message": utils.GetValidationError(err),
+			},
+		})
+		return
+	}
+
+	warning, total, err := h.warningService.Issue(c.Request.Context(), uint(userID), req.Reason, req.Note, actorFromContext(c))
+	if err != nil {
+		switch err {
+		case services.ErrUnknownWarningReason:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "validation_error",
+					"message": "Unknown warning reason",
+				},
+			})
+		case services.ErrWarningNoteRequired:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "validation_error",
+					"message": "note is required for this warning reason",
+				},
+			})
+		case services.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "not_found",
+					"message": "User not found",
+				},
+			})
+		case services.ErrUnauthorized, services.ErrForbidden:
+			c.JSON(http.StatusForbidden, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "forbidden",
+					"message": "You cannot warn this user",
+				},
+			})
+		case services.ErrInvalidStatusTransition:
+			c.JSON(http.StatusConflict, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "invalid_status_transition",
+					"message": "The user cannot be banned from the current status",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to issue warning",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"warning":      warning,
+			"total_points": total,
+		},
+	})
+}
+
+// ListForUser kullanıcının uyarılarını ve geçerli puanını listeler
+func (h *WarningHandler) ListForUser(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid user ID",
+			},
+		})
+		return
+	}
+
+	h.respondWarnings(c, uint(userID))
+}
+
+// ListOwn giriş yapan kullanıcının kendi uyarılarını listeler
+func (h *WarningHandler) ListOwn(c *gin.Context) {
+	h.respondWarnings(c, c.GetUint("user_id"))
+}
+
+// Acknowledge kullanıcının bir uyarıyı okuduğunu onaylamasını sağlar
+func (h *WarningHandler) Acknowledge(c *gin.Context) {
+	warningID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error": gin.H{
+				"code":    "invalid_id",
+				"message": "Invalid warning ID",
+			},
+		})
+		return
+	}
+
+	warning, err := h.warningService.Acknowledge(c.Request.Context(), c.GetUint("user_id"), uint(warningID))
+	if err != nil {
+		switch err {
+		case services.ErrWarningNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "not_found",
+					"message": "Warning not found",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to acknowledge warning",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"warning": warning,
+		},
+	})
+}
+
+func (h *WarningHandler) respondWarnings(c *gin.Context, userID uint) {
+	warnings, points, err := h.warningService.ListForUser(userID)
+	if err != nil {
+		switch err {
+		case services.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "not_found",
+					"message": "User not found",
+				},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to list warnings",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"warnings":      warnings,
+			"active_points": points,
+		},
+	})
+}
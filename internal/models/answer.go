@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Answer is an answer to a question. Body is markdown.
+type Answer struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	QuestionID uint           `json:"question_id" gorm:"not null"`
+	AuthorID   uint           `json:"author_id" gorm:"not null"`
+	Author     User           `json:"-" gorm:"foreignKey:AuthorID"`
+	Body       string         `json:"body" gorm:"not null"`
+	Score      int            `json:"score"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// TableName specifies the table name for GORM
+func (Answer) TableName() string {
+	return "answers"
+}
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+type ApprovalStatus string
+
+const (
+	ApprovalActionAssignAdminRole = "role.assign_admin"
+	ApprovalActionDeleteAccount   = "account.delete"
+	ApprovalActionBulkBan         = "bulk.ban"
+	ApprovalActionBulkAssignAdmin = "bulk.assign_admin"
+	ApprovalActionInviteAdmin     = "invitation.assign_admin"
+
+	ApprovalPending  ApprovalStatus = "pending"
+	ApprovalApproved ApprovalStatus = "approved"
+	ApprovalExecuted ApprovalStatus = "executed"
+	ApprovalFailed   ApprovalStatus = "failed"
+	ApprovalRejected ApprovalStatus = "rejected"
+	ApprovalExpired  ApprovalStatus = "expired"
+)
+
+// ApprovalRolePayload is the payload of a role.assign_admin request
+type ApprovalRolePayload struct {
+	Role UserRole `json:"role"`
+}
+
+// ApprovalInvitationPayload is the payload of an invitation.assign_admin request
+type ApprovalInvitationPayload struct {
+	Email string   `json:"email"`
+	Role  UserRole `json:"role"`
+}
+
+// ApprovalBulkPayload is the payload of bulk approval requests
+type ApprovalBulkPayload struct {
+	Action  BulkAction       `json:"action"`
+	Params  BulkActionParams `json:"params"`
+	UserIDs []uint           `json:"user_ids"`
+}
+
+// ApprovalRequest represents a sensitive admin operation waiting for a second admin
+type ApprovalRequest struct {
+	ID             uint           `json:"id" gorm:"primaryKey"`
+	Action         string         `json:"action" gorm:"not null"`
+	Payload        string         `json:"payload" gorm:"not null"`
+	TargetUserID   *uint          `json:"target_user_id,omitempty"`
+	RequestedBy    uint           `json:"requested_by" gorm:"not null"`
+	Status         ApprovalStatus `json:"status"`
+	DecidedBy      *uint          `json:"decided_by,omitempty"`
+	DecidedAt      *time.Time     `json:"decided_at,omitempty"`
+	DecisionReason string         `json:"decision_reason,omitempty"`
+	ExecutionError string         `json:"execution_error,omitempty"`
+	Result         string         `json:"result,omitempty"`
+	ExpiresAt      time.Time      `json:"expires_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ApprovalRequest) TableName() string {
+	return "approval_requests"
+}
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// Audit actions
+const (
+	AuditActionRoleChanged          = "user.role_changed"
+	AuditActionStatusChanged        = "user.status_changed"
+	AuditActionUserBanned           = "user.banned"
+	AuditActionUserUnbanned         = "user.unbanned"
+	AuditActionUserFrozen           = "user.frozen"
+	AuditActionUserDeleted          = "user.deleted"
+	AuditActionUserRestored         = "user.restored"
+	AuditActionUserReactivated      = "user.reactivated"
+	AuditActionUserAnonymized       = "user.anonymized"
+	AuditActionUserPassivated       = "user.passivated"
+	AuditActionUserWarned           = "user.warned"
+	AuditActionBulkJobStarted       = "bulk_job.started"
+	AuditActionRootGranted          = "user.root_granted"
+	AuditActionRootRevoked          = "user.root_revoked"
+	AuditActionInvitationCreated    = "invitation.created"
+	AuditActionInvitationAccepted   = "invitation.accepted"
+	AuditActionInvitationRevoked    = "invitation.revoked"
+	AuditActionImpersonationStarted = "impersonation.started"
+	AuditActionImpersonationStopped = "impersonation.stopped"
+	AuditActionIPRuleCreated        = "ip_rule.created"
+	AuditActionIPRuleDeleted        = "ip_rule.deleted"
+	AuditActionQuestionDeleted      = "question.deleted"
+	AuditActionAnswerDeleted        = "answer.deleted"
+	AuditActionCommentDeleted       = "comment.deleted"
+	AuditActionApprovalRequested    = "approval.requested"
+	AuditActionApprovalApproved     = "approval.approved"
+	AuditActionApprovalRejected     = "approval.rejected"
+	AuditActionApprovalExpired      = "approval.expired"
+	AuditActionTagSynonymApproved   = "tag.synonym_approved"
+	AuditActionTagSynonymRejected   = "tag.synonym_rejected"
+	AuditActionTagMergeStarted      = "tag.merge_started"
+)
+
+// AuditEvent represents a recorded administrative or security relevant action.
+// Events are append-only and chained by hash to detect tampering.
+type AuditEvent struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	ActorID      *uint     `json:"actor_id"`
+	TargetUserID *uint     `json:"target_user_id"`
+	Action       string    `json:"action" gorm:"not null"`
+	Details      string    `json:"details"`
+	BeforeState  string    `json:"before"`
+	AfterState   string    `json:"after"`
+	IP           string    `json:"ip"`
+	RequestID    string    `json:"request_id"`
+	PrevHash     string    `json:"prev_hash"`
+	Hash         string    `json:"hash"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
@@ -38,7 +38,7 @@ type UpdateUserRoleRequest struct {
 
 // UpdateUserStatusRequest represents the model for updating user status request
 type UpdateUserStatusRequest struct {
-	Status UserStatus `json:"status" validate:"required,oneof=active passive banned"`
+	Status UserStatus `json:"status" validate:"required,oneof=active passive"`
 }
 
 // UpdateProfileRequest represents the model for updating user profile request
@@ -55,6 +55,11 @@ type UpdatePasswordRequest struct {
 	ConfirmPassword string `json:"confirm_password" validate:"required,eqfield=NewPassword"`
 }
 
+// UnbanUserRequest represents the model for unbanning user request
+type UnbanUserRequest struct {
+	UserID uint `json:"user_id" validate:"required"`
+}
+
 // BanUserRequest represents the model for banning user request
 type BanUserRequest struct {
 	UserID      uint   `json:"user_id" validate:"required"`
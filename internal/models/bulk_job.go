@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+type BulkAction string
+type BulkJobStatus string
+
+const (
+	BulkActionBan    BulkAction = "ban"
+	BulkActionUnban  BulkAction = "unban"
+	BulkActionStatus BulkAction = "status"
+	BulkActionRole   BulkAction = "role"
+
+	BulkJobPending   BulkJobStatus = "pending"
+	BulkJobRunning   BulkJobStatus = "running"
+	BulkJobCompleted BulkJobStatus = "completed"
+	BulkJobFailed    BulkJobStatus = "failed"
+)
+
+// BulkActionParams holds the parameters of a bulk action, only the ones relevant
+// to the action are used
+type BulkActionParams struct {
+	BanReason   string     `json:"ban_reason,omitempty"`
+	BanDuration string     `json:"ban_duration,omitempty"`
+	Status      UserStatus `json:"status,omitempty"`
+	Role        UserRole   `json:"role,omitempty"`
+}
+
+// BulkRowResult is the outcome of a bulk action for a single user
+type BulkRowResult struct {
+	UserID uint   `json:"user_id"`
+	Result string `json:"result"` // applied, would_apply, skipped, failed
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkJob represents a bulk administrative action on many users
+type BulkJob struct {
+	ID          uint          `json:"id" gorm:"primaryKey"`
+	ActorID     uint          `json:"actor_id" gorm:"not null"`
+	Action      BulkAction    `json:"action"`
+	Params      string        `json:"-" gorm:"type:jsonb"`
+	DryRun      bool          `json:"dry_run"`
+	Status      BulkJobStatus `json:"status"`
+	Total       int           `json:"total"`
+	Processed   int           `json:"processed"`
+	Succeeded   int           `json:"succeeded"`
+	Failed      int           `json:"failed"`
+	Results     string        `json:"-" gorm:"type:jsonb"`
+	CreatedAt   time.Time     `json:"created_at"`
+	CompletedAt *time.Time    `json:"completed_at,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (BulkJob) TableName() string {
+	return "bulk_jobs"
+}
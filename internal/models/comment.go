@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Comment is a short remark on a question or an answer. Body is markdown-lite:
+// inline formatting only, on a single line.
+type Comment struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	PostType  PostType       `json:"post_type" gorm:"not null"`
+	PostID    uint           `json:"post_id" gorm:"not null"`
+	AuthorID  uint           `json:"author_id" gorm:"not null"`
+	Author    User           `json:"-" gorm:"foreignKey:AuthorID"`
+	Body      string         `json:"body" gorm:"not null"`
+	Score     int            `json:"score"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// TableName specifies the table name for GORM
+func (Comment) TableName() string {
+	return "comments"
+}
+
+// CommentVote is the upvote of a user on a comment
+type CommentVote struct {
+	UserID    uint      `json:"-" gorm:"primaryKey"`
+	CommentID uint      `json:"comment_id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (CommentVote) TableName() string {
+	return "comment_votes"
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+type DataExportStatus string
+
+const (
+	ExportStatusPending    DataExportStatus = "pending"
+	ExportStatusProcessing DataExportStatus = "processing"
+	ExportStatusReady      DataExportStatus = "ready"
+	ExportStatusFailed     DataExportStatus = "failed"
+	ExportStatusExpired    DataExportStatus = "expired"
+)
+
+// DataExport represents a personal data export requested by a user
+type DataExport struct {
+	ID          uint             `json:"id" gorm:"primaryKey"`
+	UserID      uint             `json:"user_id" gorm:"not null"`
+	Status      DataExportStatus `json:"status"`
+	Token       string           `json:"-" gorm:"not null"`
+	FilePath    string           `json:"-"`
+	Error       string           `json:"-"`
+	CreatedAt   time.Time        `json:"created_at"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time       `json:"expires_at,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (DataExport) TableName() string {
+	return "data_exports"
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// ErasureTombstone records that the personal data of a deleted account was erased.
+// It intentionally contains no personal data itself.
+type ErasureTombstone struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	UserID         uint      `json:"user_id" gorm:"not null"`
+	DeletedAt      time.Time `json:"deleted_at"`
+	ErasedAt       time.Time `json:"erased_at"`
+	ErasedFields   string    `json:"erased_fields"`
+	RecordsDeleted string    `json:"records_deleted" gorm:"type:jsonb"`
+	Reason         string    `json:"reason"`
+}
+
+// TableName specifies the table name for GORM
+func (ErasureTombstone) TableName() string {
+	return "erasure_tombstones"
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// ImpersonationSession is a SUPER_ADMIN acting as a user with a restricted token
+type ImpersonationSession struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	ImpersonatorID uint       `json:"impersonator_id" gorm:"not null"`
+	UserID         uint       `json:"user_id" gorm:"not null"`
+	Reason         string     `json:"reason" gorm:"not null"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	EndedAt        *time.Time `json:"ended_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ImpersonationSession) TableName() string {
+	return "impersonation_sessions"
+}
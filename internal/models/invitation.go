@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationRevoked  InvitationStatus = "revoked"
+	InvitationExpired  InvitationStatus = "expired"
+)
+
+// AdminInvitation is a single-use invitation that gives the invited email address
+// a preassigned role. Expired invitations keep the pending status in the database.
+type AdminInvitation struct {
+	ID             uint             `json:"id" gorm:"primaryKey"`
+	Email          string           `json:"email" gorm:"not null"`
+	Role           UserRole         `json:"role" gorm:"type:user_role"`
+	NonceHash      string           `json:"-" gorm:"not null"`
+	Status         InvitationStatus `json:"status"`
+	InvitedBy      uint             `json:"invited_by" gorm:"not null"`
+	AcceptedUserID *uint            `json:"accepted_user_id,omitempty"`
+	AcceptedAt     *time.Time       `json:"accepted_at,omitempty"`
+	RevokedBy      *uint            `json:"revoked_by,omitempty"`
+	RevokedAt      *time.Time       `json:"revoked_at,omitempty"`
+	ExpiresAt      time.Time        `json:"expires_at"`
+	CreatedAt      time.Time        `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (AdminInvitation) TableName() string {
+	return "admin_invitations"
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// IP rule actions
+const (
+	IPRuleBlock = "block"
+	IPRuleAllow = "allow"
+)
+
+// IP rule scopes, "all" applies to every route
+const (
+	IPRuleScopeRegister = "register"
+	IPRuleScopeLogin    = "login"
+	IPRuleScopeAll      = "all"
+)
+
+// IPRule blocks or allows an IP address or CIDR range. Allow rules take precedence
+// over block rules of the same scope, e.g. to exempt an office network.
+type IPRule struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	CIDR      string     `json:"cidr" gorm:"column:cidr;not null"`
+	Action    string     `json:"action" gorm:"not null"`
+	Scope     string     `json:"scope" gorm:"not null"`
+	Reason    string     `json:"reason" gorm:"not null"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	HitCount  int64      `json:"hit_count"`
+	LastHitAt *time.Time `json:"last_hit_at"`
+	CreatedBy uint       `json:"created_by" gorm:"not null"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (IPRule) TableName() string {
+	return "ip_rules"
+}
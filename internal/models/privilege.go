@@ -0,0 +1,17 @@
+package models
+
+// Privileges unlocked by reputation, independent of the user's role
+const (
+	PrivilegeVoteUp            = "vote_up"
+	PrivilegeCommentEverywhere = "comment_everywhere"
+	PrivilegeVoteDown          = "vote_down"
+	PrivilegeEditPosts         = "edit_posts"
+	PrivilegeVoteToClose       = "vote_to_close"
+)
+
+// Privilege is an action that needs a minimum reputation
+type Privilege struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Reputation  int    `json:"reputation"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type QuestionStatus string
+
+const (
+	QuestionOpen   QuestionStatus = "open"
+	QuestionClosed QuestionStatus = "closed"
+)
+
+// Question is a question asked on the platform. Body is markdown.
+type Question struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	Title     string         `json:"title" gorm:"not null"`
+	Body      string         `json:"body" gorm:"not null"`
+	AuthorID  uint           `json:"author_id" gorm:"not null"`
+	Author    User           `json:"-" gorm:"foreignKey:AuthorID"`
+	Status    QuestionStatus `json:"status"`
+	Score     int            `json:"score"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	AcceptedAnswerID *uint   `json:"accepted_answer_id"`
+	AcceptedAnswer   *Answer `json:"-" gorm:"foreignKey:AcceptedAnswerID"`
+	Tags             []Tag   `json:"-" gorm:"many2many:question_tags"`
+}
+
+// TableName specifies the table name for GORM
+func (Question) TableName() string {
+	return "questions"
+}
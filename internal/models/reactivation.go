@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// AccountReactivationToken is a single-use link emailed to an account that was
+// made passive for inactivity
+type AccountReactivationToken struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null"`
+	TokenHash string     `json:"-" gorm:"not null"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (AccountReactivationToken) TableName() string {
+	return "account_reactivation_tokens"
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// ReputationReason tells why the reputation of a user changed
+type ReputationReason string
+
+const (
+	ReputationQuestionUpvoted   ReputationReason = "question_upvoted"
+	ReputationQuestionDownvoted ReputationReason = "question_downvoted"
+	ReputationAnswerUpvoted     ReputationReason = "answer_upvoted"
+	ReputationAnswerDownvoted   ReputationReason = "answer_downvoted"
+	// ReputationDownvoteCast is the cost of downvoting an answer, charged to the voter
+	ReputationDownvoteCast ReputationReason = "downvote_cast"
+	// ReputationAnswerAccepted is earned by the author of the accepted answer
+	ReputationAnswerAccepted ReputationReason = "answer_accepted"
+	// ReputationAcceptedAnswer is earned by the question author for accepting an answer
+	ReputationAcceptedAnswer ReputationReason = "accepted_answer"
+)
+
+// ReputationEvent is an append-only ledger entry. A change is undone by adding a
+// reversal entry with the negated delta, entries are never updated or deleted.
+type ReputationEvent struct {
+	ID         uint             `json:"id" gorm:"primaryKey"`
+	UserID     uint             `json:"user_id" gorm:"not null"`
+	Delta      int              `json:"delta" gorm:"not null"`
+	Reason     ReputationReason `json:"reason" gorm:"not null"`
+	PostType   *PostType        `json:"post_type,omitempty"`
+	PostID     *uint            `json:"post_id,omitempty"`
+	VoteID     *uint            `json:"-"`
+	ActorID    *uint            `json:"-"`
+	ReversalOf *uint            `json:"reversal_of,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ReputationEvent) TableName() string {
+	return "reputation_events"
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// PostRevision is a snapshot of a question or an answer after an edit. Revision 1 is
+// the post as it was created. Title and Tags are empty for answers; Tags holds the tag
+// names separated by spaces.
+type PostRevision struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	PostType   PostType  `json:"post_type" gorm:"not null"`
+	PostID     uint      `json:"post_id" gorm:"not null"`
+	Revision   int       `json:"revision" gorm:"not null"`
+	Title      string    `json:"title,omitempty"`
+	Body       string    `json:"body"`
+	Tags       string    `json:"tags,omitempty"`
+	EditorID   uint      `json:"editor_id" gorm:"not null"`
+	Editor     User      `json:"-" gorm:"foreignKey:EditorID"`
+	Summary    string    `json:"summary"`
+	RollbackOf *int      `json:"rollback_of"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (PostRevision) TableName() string {
+	return "post_revisions"
+}
+
+// TagNames returns the tags of the revision
+func (r *PostRevision) TagNames() []string {
+	return strings.Fields(r.Tags)
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// RootAdminChallenge is a one-time code emailed to a root admin to confirm
+// granting, transferring or revoking root status
+type RootAdminChallenge struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null"`
+	CodeHash  string     `json:"-" gorm:"not null"`
+	Attempts  int        `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (RootAdminChallenge) TableName() string {
+	return "root_admin_challenges"
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// StatusEvent names a transition of the account status state machine
+type StatusEvent string
+
+const (
+	StatusEventActivate   StatusEvent = "activate"
+	StatusEventDeactivate StatusEvent = "deactivate"
+	StatusEventBan        StatusEvent = "ban"
+	StatusEventUnban      StatusEvent = "unban"
+	StatusEventFreeze     StatusEvent = "freeze"
+	StatusEventDelete     StatusEvent = "delete"
+	StatusEventReactivate StatusEvent = "reactivate"
+	StatusEventRestore    StatusEvent = "restore"
+	StatusEventPassivate  StatusEvent = "passivate"
+)
+
+// Who triggered a status transition
+const (
+	StatusTriggerSelf   = "self"
+	StatusTriggerAdmin  = "admin"
+	StatusTriggerSystem = "system"
+)
+
+// UserStatusHistory records a single status transition of a user
+type UserStatusHistory struct {
+	ID          uint        `json:"id" gorm:"primaryKey"`
+	UserID      uint        `json:"user_id" gorm:"not null"`
+	Event       StatusEvent `json:"event" gorm:"not null"`
+	FromStatus  UserStatus  `json:"from_status" gorm:"type:user_status"`
+	ToStatus    UserStatus  `json:"to_status" gorm:"type:user_status"`
+	TriggeredBy string      `json:"triggered_by" gorm:"not null"`
+	ActorID     *uint       `json:"actor_id,omitempty"`
+	Reason      string      `json:"reason,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (UserStatusHistory) TableName() string {
+	return "user_status_history"
+}
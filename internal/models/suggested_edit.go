@@ -0,0 +1,61 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// SuggestedEditStatus is the review state of a suggested edit
+type SuggestedEditStatus string
+
+const (
+	SuggestedEditPending  SuggestedEditStatus = "pending"
+	SuggestedEditApproved SuggestedEditStatus = "approved"
+	SuggestedEditImproved SuggestedEditStatus = "improved"
+	SuggestedEditRejected SuggestedEditStatus = "rejected"
+)
+
+// SuggestedEdit is an edit proposed by a user who cannot edit the post directly. It
+// holds the full proposed content of the post, based on BaseRevision. Once approved,
+// Revision is the revision it became.
+type SuggestedEdit struct {
+	ID           uint                `json:"id" gorm:"primaryKey"`
+	PostType     PostType            `json:"post_type" gorm:"not null"`
+	PostID       uint                `json:"post_id" gorm:"not null"`
+	BaseRevision int                 `json:"base_revision" gorm:"not null"`
+	Title        string              `json:"title,omitempty"`
+	Body         string              `json:"body"`
+	Tags         string              `json:"tags,omitempty"`
+	Summary      string              `json:"summary"`
+	AuthorID     uint                `json:"author_id" gorm:"not null"`
+	Author       User                `json:"-" gorm:"foreignKey:AuthorID"`
+	Status       SuggestedEditStatus `json:"status"`
+	ReviewerID   *uint               `json:"reviewer_id"`
+	Reviewer     *User               `json:"-" gorm:"foreignKey:ReviewerID"`
+	RejectReason string              `json:"reject_reason,omitempty"`
+	Revision     *int                `json:"revision"`
+	CreatedAt    time.Time           `json:"created_at"`
+	ReviewedAt   *time.Time          `json:"reviewed_at"`
+}
+
+// TableName specifies the table name for GORM
+func (SuggestedEdit) TableName() string {
+	return "suggested_edits"
+}
+
+// TagNames returns the proposed tags of a question
+func (e *SuggestedEdit) TagNames() []string {
+	return strings.Fields(e.Tags)
+}
+
+// ProposedRevision returns the proposed content in the form of a revision, to compare
+// it with the revisions of the post
+func (e *SuggestedEdit) ProposedRevision() *PostRevision {
+	return &PostRevision{
+		PostType: e.PostType,
+		PostID:   e.PostID,
+		Title:    e.Title,
+		Body:     e.Body,
+		Tags:     e.Tags,
+	}
+}
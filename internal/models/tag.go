@@ -0,0 +1,95 @@
+package models
+
+import "time"
+
+type TagPreference string
+type TagSynonymStatus string
+type TagMergeStatus string
+
+const (
+	TagFollow TagPreference = "follow"
+	TagIgnore TagPreference = "ignore"
+
+	TagSynonymPending  TagSynonymStatus = "pending"
+	TagSynonymApproved TagSynonymStatus = "approved"
+	TagSynonymRejected TagSynonymStatus = "rejected"
+
+	TagMergePending   TagMergeStatus = "pending"
+	TagMergeRunning   TagMergeStatus = "running"
+	TagMergeCompleted TagMergeStatus = "completed"
+	TagMergeFailed    TagMergeStatus = "failed"
+)
+
+// Tag categorizes questions. QuestionCount is the number of questions carrying the tag.
+type Tag struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	Name          string    `json:"name" gorm:"not null"`
+	Excerpt       string    `json:"excerpt"`
+	Wiki          string    `json:"wiki,omitempty"`
+	QuestionCount int       `json:"question_count"`
+	WikiUpdatedBy *uint     `json:"-"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// UserTagPreference records that a user follows or ignores a tag
+type UserTagPreference struct {
+	UserID     uint          `json:"-" gorm:"primaryKey"`
+	TagID      uint          `json:"-" gorm:"primaryKey"`
+	Tag        Tag           `json:"tag" gorm:"foreignKey:TagID"`
+	Preference TagPreference `json:"preference" gorm:"not null"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (UserTagPreference) TableName() string {
+	return "user_tag_preferences"
+}
+
+// TagSynonym maps another name of a topic to its tag. Questions tagged with an
+// approved synonym get the target tag instead.
+type TagSynonym struct {
+	ID           uint             `json:"id" gorm:"primaryKey"`
+	Synonym      string           `json:"synonym" gorm:"not null"`
+	TargetTagID  uint             `json:"-" gorm:"not null"`
+	Target       Tag              `json:"target" gorm:"foreignKey:TargetTagID"`
+	Status       TagSynonymStatus `json:"status"`
+	ProposedBy   uint             `json:"proposed_by" gorm:"not null"`
+	ReviewedBy   *uint            `json:"reviewed_by"`
+	RejectReason string           `json:"reject_reason,omitempty"`
+	CreatedAt    time.Time        `json:"created_at"`
+	ReviewedAt   *time.Time       `json:"reviewed_at"`
+}
+
+// TableName specifies the table name for GORM
+func (TagSynonym) TableName() string {
+	return "tag_synonyms"
+}
+
+// TagMerge moves the questions of a source tag to a target tag in the background.
+// The tag IDs are cleared once the tags are deleted, the names are kept.
+type TagMerge struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	SourceTagID *uint          `json:"-"`
+	SourceName  string         `json:"source"`
+	TargetTagID *uint          `json:"-"`
+	TargetName  string         `json:"target"`
+	ActorID     uint           `json:"actor_id" gorm:"not null"`
+	Status      TagMergeStatus `json:"status"`
+	Total       int            `json:"total"`
+	Processed   int            `json:"processed"`
+	Error       string         `json:"error,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	CompletedAt *time.Time     `json:"completed_at,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (TagMerge) TableName() string {
+	return "tag_merges"
+}
@@ -30,6 +30,7 @@ type User struct {
 	Avatar        string         `json:"avatar"`
 	Status        UserStatus     `json:"status" gorm:"type:user_status"`
 	Role          UserRole       `json:"role" gorm:"type:user_role"`
+	Reputation    int            `json:"reputation" gorm:"default:1"`
 	IsRootAdmin   bool          `json:"-"`
 	CreatedAt     time.Time      `json:"created_at"`
 	LastLoginDate time.Time      `json:"last_login_date"`
@@ -37,6 +38,8 @@ type User struct {
 	BanEndDate    *time.Time     `json:"ban_end_date,omitempty"`
 	FrozenReason  string         `json:"frozen_reason,omitempty"`
 	FrozenDate    *time.Time     `json:"frozen_date,omitempty"`
+	AnonymizedAt  *time.Time     `json:"anonymized_at,omitempty"`
+	InactivityWarnedAt *time.Time `json:"-"`
 	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// PostType identifies the kind of post a vote, and later other records, refer to
+type PostType string
+
+const (
+	PostTypeQuestion PostType = "question"
+	PostTypeAnswer   PostType = "answer"
+)
+
+const (
+	VoteUp   = 1
+	VoteDown = -1
+)
+
+// Vote is the vote of a user on a question or an answer
+type Vote struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null"`
+	PostType  PostType  `json:"post_type" gorm:"not null"`
+	PostID    uint      `json:"post_id" gorm:"not null"`
+	Value     int       `json:"value" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Vote) TableName() string {
+	return "votes"
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Canned warning reasons
+const (
+	WarningReasonSpam          = "spam"
+	WarningReasonHarassment    = "harassment"
+	WarningReasonOffTopic      = "off_topic"
+	WarningReasonPlagiarism    = "plagiarism"
+	WarningReasonInappropriate = "inappropriate_content"
+	WarningReasonVoteFraud     = "vote_fraud"
+	WarningReasonOther         = "other"
+)
+
+// WarningReason describes a canned reason moderators pick when warning a user
+type WarningReason struct {
+	Code         string `json:"code"`
+	Description  string `json:"description"`
+	Points       int    `json:"points"`
+	NoteRequired bool   `json:"note_required"`
+}
+
+// UserWarning is a formal warning issued to a user. Its points count towards
+// automatic bans until ExpiresAt.
+type UserWarning struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	UserID         uint       `json:"user_id" gorm:"not null"`
+	IssuedBy       uint       `json:"issued_by" gorm:"not null"`
+	Reason         string     `json:"reason" gorm:"not null"`
+	Note           string     `json:"note,omitempty"`
+	Points         int        `json:"points"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+	EscalatedBan   string     `json:"escalated_ban,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (UserWarning) TableName() string {
+	return "user_warnings"
+}
@@ -2,21 +2,58 @@ package routes
 
 import (
 	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/anilsoylu/answer-backend/internal/models"
 	"github.com/anilsoylu/answer-backend/pkg/middleware"
 	"github.com/gin-gonic/gin"
 )
 
-func SetupAdminRoutes(router *gin.Engine, authHandler *handlers.AuthHandler) {
+func SetupAdminRoutes(router *gin.Engine, authHandler *handlers.AuthHandler, adminHandler *handlers.AdminHandler, bulkHandler *handlers.BulkHandler, auditHandler *handlers.AuditHandler, approvalHandler *handlers.ApprovalHandler, rootAdminHandler *handlers.RootAdminHandler) {
 	admin := router.Group("/api/v1/admin")
 	{
 		// Public admin routes
-		admin.POST("/login", authHandler.AdminLogin)
+		admin.POST("/login", middleware.IPFilter(models.IPRuleScopeLogin), authHandler.AdminLogin)
 
 		// Protected admin routes
 		protected := admin.Group("")
 		protected.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
 		{
 			protected.GET("/me", authHandler.Me)
+
+			users := protected.Group("/users")
+			{
+				users.GET("", adminHandler.ListUsers)
+				users.GET("/:id", adminHandler.GetUser)
+				users.GET("/deleted", adminHandler.ListDeletedUsers)
+				users.GET("/deleted/:id", adminHandler.GetDeletedUser)
+				users.POST("/:id/restore", adminHandler.RestoreUser)
+				users.GET("/:id/status-history", adminHandler.GetStatusHistory)
+				users.POST("/bulk", bulkHandler.Start)
+				users.GET("/bulk/:id", bulkHandler.Get)
+			}
+
+			audit := protected.Group("/audit")
+			{
+				audit.GET("", auditHandler.List)
+				audit.GET("/export", auditHandler.Export)
+				audit.GET("/verify", auditHandler.Verify)
+			}
+
+			approvals := protected.Group("/approvals")
+			{
+				approvals.GET("", approvalHandler.List)
+				approvals.GET("/:id", approvalHandler.Get)
+				approvals.POST("/:id/approve", approvalHandler.Approve)
+				approvals.POST("/:id/reject", approvalHandler.Reject)
+			}
+
+			rootAdmins := protected.Group("/root-admins")
+			{
+				rootAdmins.GET("", rootAdminHandler.List)
+				rootAdmins.POST("/verification-code", rootAdminHandler.SendVerificationCode)
+				rootAdmins.POST("/grant", rootAdminHandler.Grant)
+				rootAdmins.POST("/transfer", rootAdminHandler.Transfer)
+				rootAdmins.POST("/revoke", rootAdminHandler.Revoke)
+			}
 		}
 	}
 } 
\ No newline at end of file
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/anilsoylu/answer-backend/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAnswerRoutes(router *gin.Engine, answerHandler *handlers.AnswerHandler) {
+	router.GET("/api/v1/questions/:id/answers", middleware.OptionalAuthMiddleware(), answerHandler.List)
+
+	protected := router.Group("/api/v1")
+	protected.Use(middleware.AuthMiddleware(), middleware.RequireAcknowledgedWarnings())
+	{
+		protected.POST("/questions/:id/answers", answerHandler.Create)
+		protected.PUT("/answers/:id", answerHandler.Update)
+		protected.DELETE("/answers/:id", middleware.BlockImpersonation(), answerHandler.Delete)
+		protected.POST("/answers/:id/accept", answerHandler.Accept)
+		protected.DELETE("/answers/:id/accept", answerHandler.Unaccept)
+	}
+}
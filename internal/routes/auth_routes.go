@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/anilsoylu/answer-backend/internal/models"
 	"github.com/anilsoylu/answer-backend/pkg/middleware"
 	"github.com/gin-gonic/gin"
 )
@@ -9,8 +10,9 @@ import (
 func SetupAuthRoutes(router *gin.Engine, authHandler *handlers.AuthHandler) {
 	auth := router.Group("/api/v1/auth")
 	{
-		auth.POST("/register", authHandler.Register)
-		auth.POST("/login", authHandler.Login)
+		auth.POST("/register", middleware.IPFilter(models.IPRuleScopeRegister), authHandler.Register)
+		auth.POST("/login", middleware.IPFilter(models.IPRuleScopeLogin), authHandler.Login)
+		auth.POST("/reactivate", middleware.IPFilter(models.IPRuleScopeLogin), authHandler.ReactivateAccount)
 	}
 
 	// Protected routes
@@ -19,12 +21,17 @@ func SetupAuthRoutes(router *gin.Engine, authHandler *handlers.AuthHandler) {
 	{
 		users := protected.Group("/users")
 		{
-			users.POST("/freeze", authHandler.FreezeAccount)
-			users.DELETE("/:id", authHandler.DeleteAccount)
-			users.PUT("/status", authHandler.UpdateUserStatus)
-			users.PUT("/role", authHandler.UpdateUserRole)
-			users.PUT("/profile", authHandler.UpdateProfile)
-			users.PUT("/password", authHandler.UpdatePassword)
+			users.GET("/me", authHandler.Me)
+			users.PUT("/profile", middleware.BlockImpersonation(), middleware.RequireAcknowledgedWarnings(), authHandler.UpdateProfile)
+
+			// Kullanıcı adına açılan oturumlarda yıkıcı işlemler engellenir
+			users.POST("/freeze", middleware.BlockImpersonation(), authHandler.FreezeAccount)
+			users.DELETE("/:id", middleware.BlockImpersonation(), authHandler.DeleteAccount)
+			users.PUT("/status", middleware.BlockImpersonation(), authHandler.UpdateUserStatus)
+			users.POST("/ban", middleware.BlockImpersonation(), authHandler.BanUser)
+			users.POST("/unban", middleware.BlockImpersonation(), authHandler.UnbanUser)
+			users.PUT("/role", middleware.BlockImpersonation(), authHandler.UpdateUserRole)
+			users.PUT("/password", middleware.BlockImpersonation(), authHandler.UpdatePassword)
 		}
 	}
 } 
\ No newline at end of file
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupCommentRoutes(router *gin.Engine, commentHandler *handlers.CommentHandler) {
+	public := router.Group("/api/v1")
+	public.Use(middleware.OptionalAuthMiddleware())
+	{
+		public.GET("/questions/:id/comments", commentHandler.List(models.PostTypeQuestion))
+		public.GET("/answers/:id/comments", commentHandler.List(models.PostTypeAnswer))
+	}
+
+	protected := router.Group("/api/v1")
+	protected.Use(middleware.AuthMiddleware(), middleware.RequireAcknowledgedWarnings())
+	{
+		protected.POST("/questions/:id/comments", commentHandler.Create(models.PostTypeQuestion))
+		protected.POST("/answers/:id/comments", commentHandler.Create(models.PostTypeAnswer))
+		protected.PUT("/comments/:id", commentHandler.Update)
+		protected.DELETE("/comments/:id", middleware.BlockImpersonation(), commentHandler.Delete)
+		protected.POST("/comments/:id/upvote", middleware.RequirePrivilege(models.PrivilegeVoteUp), commentHandler.Upvote)
+		protected.DELETE("/comments/:id/upvote", commentHandler.RetractUpvote)
+	}
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/anilsoylu/answer-backend/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupExportRoutes(router *gin.Engine, exportHandler *handlers.ExportHandler) {
+	// Download links are sent by email, the token itself authorizes the download
+	router.GET("/api/v1/exports/:token", exportHandler.Download)
+
+	protected := router.Group("/api/v1/users/me")
+	protected.Use(middleware.AuthMiddleware())
+	{
+		protected.POST("/export", middleware.BlockImpersonation(), exportHandler.RequestExport)
+		protected.GET("/export", exportHandler.GetExport)
+	}
+}
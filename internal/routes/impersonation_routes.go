@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/anilsoylu/answer-backend/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupImpersonationRoutes(router *gin.Engine, impersonationHandler *handlers.ImpersonationHandler) {
+	admin := router.Group("/api/v1/admin/impersonation")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	{
+		admin.POST("", impersonationHandler.Start)
+	}
+
+	// Called with the impersonation token, which only carries the user's role
+	router.POST("/api/v1/impersonation/stop", middleware.AuthMiddleware(), impersonationHandler.Stop)
+}
@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupInactivityRoutes(router *gin.Engine, inactivityHandler *handlers.InactivityHandler) {
+	// Public, the emailed token identifies the account
+	router.POST("/api/v1/auth/reactivate/link", inactivityHandler.ReactivateWithLink)
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupInvitationRoutes(router *gin.Engine, invitationHandler *handlers.InvitationHandler) {
+	// Davetiye bağlantısı ile kullanılan public route'lar
+	invitations := router.Group("/api/v1/invitations")
+	{
+		invitations.GET("", invitationHandler.Get)
+		invitations.POST("/accept", middleware.IPFilter(models.IPRuleScopeRegister), invitationHandler.Accept)
+	}
+
+	admin := router.Group("/api/v1/admin/invitations")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	{
+		admin.GET("", invitationHandler.List)
+		admin.POST("", invitationHandler.Create)
+		admin.DELETE("/:id", invitationHandler.Revoke)
+	}
+}
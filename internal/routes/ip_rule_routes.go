@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/anilsoylu/answer-backend/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupIPRuleRoutes(router *gin.Engine, ipRuleHandler *handlers.IPRuleHandler) {
+	admin := router.Group("/api/v1/admin/ip-rules")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	{
+		admin.GET("", ipRuleHandler.List)
+		admin.POST("", middleware.BlockImpersonation(), ipRuleHandler.Create)
+		admin.DELETE("/:id", middleware.BlockImpersonation(), ipRuleHandler.Delete)
+	}
+}
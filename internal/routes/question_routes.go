@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/anilsoylu/answer-backend/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupQuestionRoutes(router *gin.Engine, questionHandler *handlers.QuestionHandler) {
+	// Giriş yapan kullanıcının oyları yanıtlara eklenir
+	public := router.Group("/api/v1/questions")
+	public.Use(middleware.OptionalAuthMiddleware())
+	{
+		public.GET("", questionHandler.List)
+		public.GET("/:id", questionHandler.Get)
+	}
+
+	protected := router.Group("/api/v1/questions")
+	protected.Use(middleware.AuthMiddleware(), middleware.RequireAcknowledgedWarnings())
+	{
+		protected.POST("", questionHandler.Create)
+		protected.PUT("/:id", questionHandler.Update)
+		protected.DELETE("/:id", middleware.BlockImpersonation(), questionHandler.Delete)
+	}
+}
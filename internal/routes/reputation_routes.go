@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupReputationRoutes(router *gin.Engine, reputationHandler *handlers.ReputationHandler, privilegeHandler *handlers.PrivilegeHandler) {
+	// İtibar geçmişi herkese açıktır, oy verenler gösterilmez
+	router.GET("/api/v1/users/:id/reputation", reputationHandler.History)
+	router.GET("/api/v1/users/:id/privileges", privilegeHandler.ForUser)
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRevisionRoutes(router *gin.Engine, revisionHandler *handlers.RevisionHandler) {
+	// Revizyon geçmişi herkese açıktır
+	public := router.Group("/api/v1")
+	{
+		public.GET("/questions/:id/revisions", revisionHandler.List(models.PostTypeQuestion))
+		public.GET("/questions/:id/revisions/diff", revisionHandler.Diff(models.PostTypeQuestion))
+		public.GET("/questions/:id/revisions/:revision", revisionHandler.Get(models.PostTypeQuestion))
+		public.GET("/answers/:id/revisions", revisionHandler.List(models.PostTypeAnswer))
+		public.GET("/answers/:id/revisions/diff", revisionHandler.Diff(models.PostTypeAnswer))
+		public.GET("/answers/:id/revisions/:revision", revisionHandler.Get(models.PostTypeAnswer))
+	}
+
+	protected := router.Group("/api/v1")
+	protected.Use(middleware.AuthMiddleware(), middleware.RequireAcknowledgedWarnings())
+	{
+		protected.POST("/questions/:id/revisions/:revision/rollback", revisionHandler.Rollback(models.PostTypeQuestion))
+		protected.POST("/answers/:id/revisions/:revision/rollback", revisionHandler.Rollback(models.PostTypeAnswer))
+	}
+}
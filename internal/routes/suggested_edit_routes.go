@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupSuggestedEditRoutes(router *gin.Engine, suggestedEditHandler *handlers.SuggestedEditHandler) {
+	router.GET("/api/v1/suggested-edits/:id", suggestedEditHandler.Get)
+
+	protected := router.Group("/api/v1")
+	protected.Use(middleware.AuthMiddleware(), middleware.RequireAcknowledgedWarnings())
+	{
+		protected.POST("/questions/:id/suggested-edits", suggestedEditHandler.Create(models.PostTypeQuestion))
+		protected.POST("/answers/:id/suggested-edits", suggestedEditHandler.Create(models.PostTypeAnswer))
+		protected.GET("/users/me/suggested-edits", suggestedEditHandler.ListMine)
+	}
+
+	// Öneriler editörler, yöneticiler ve edit_posts yetkisi olan kullanıcılar tarafından incelenir
+	review := router.Group("/api/v1/suggested-edits")
+	review.Use(middleware.AuthMiddleware(), middleware.RequireAcknowledgedWarnings())
+	{
+		review.GET("", suggestedEditHandler.List)
+		review.POST("/:id/approve", suggestedEditHandler.Approve)
+		review.POST("/:id/improve", suggestedEditHandler.Improve)
+		review.POST("/:id/reject", suggestedEditHandler.Reject)
+	}
+}
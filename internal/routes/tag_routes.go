@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/anilsoylu/answer-backend/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupTagRoutes(router *gin.Engine, tagHandler *handlers.TagHandler) {
+	public := router.Group("/api/v1/tags")
+	{
+		public.GET("", tagHandler.List)
+		public.GET("/:name", tagHandler.Get)
+	}
+
+	protected := router.Group("/api/v1/tags")
+	protected.Use(middleware.AuthMiddleware())
+	{
+		protected.PUT("/:name", middleware.RequireAcknowledgedWarnings(), tagHandler.UpdateWiki)
+		protected.PUT("/:name/preference", tagHandler.SetPreference)
+		protected.DELETE("/:name/preference", tagHandler.ClearPreference)
+	}
+
+	me := router.Group("/api/v1/users/me/tags")
+	me.Use(middleware.AuthMiddleware())
+	{
+		me.GET("", tagHandler.ListPreferences)
+	}
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/anilsoylu/answer-backend/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupTagSynonymRoutes(router *gin.Engine, tagSynonymHandler *handlers.TagSynonymHandler) {
+	router.GET("/api/v1/tags/:name/synonyms", tagSynonymHandler.ListForTag)
+	router.POST("/api/v1/tags/:name/synonyms", middleware.AuthMiddleware(), middleware.RequireAcknowledgedWarnings(), tagSynonymHandler.Propose)
+
+	// Öneriler editörler ve yöneticiler tarafından incelenir
+	review := router.Group("/api/v1/tag-synonyms")
+	review.Use(middleware.AuthMiddleware())
+	{
+		review.GET("", tagSynonymHandler.List)
+		review.POST("/:id/approve", tagSynonymHandler.Approve)
+		review.POST("/:id/reject", tagSynonymHandler.Reject)
+	}
+
+	admin := router.Group("/api/v1/admin/tag-merges")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	{
+		admin.POST("", tagSynonymHandler.StartMerge)
+		admin.GET("/:id", tagSynonymHandler.GetMerge)
+	}
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupVoteRoutes(router *gin.Engine, voteHandler *handlers.VoteHandler) {
+	protected := router.Group("/api/v1")
+	protected.Use(middleware.AuthMiddleware(), middleware.RequireAcknowledgedWarnings())
+	{
+		protected.POST("/questions/:id/upvote", middleware.RequirePrivilege(models.PrivilegeVoteUp), voteHandler.Upvote(models.PostTypeQuestion))
+		protected.POST("/questions/:id/downvote", middleware.RequirePrivilege(models.PrivilegeVoteDown), voteHandler.Downvote(models.PostTypeQuestion))
+		protected.DELETE("/questions/:id/vote", voteHandler.Retract(models.PostTypeQuestion))
+
+		protected.POST("/answers/:id/upvote", middleware.RequirePrivilege(models.PrivilegeVoteUp), voteHandler.Upvote(models.PostTypeAnswer))
+		protected.POST("/answers/:id/downvote", middleware.RequirePrivilege(models.PrivilegeVoteDown), voteHandler.Downvote(models.PostTypeAnswer))
+		protected.DELETE("/answers/:id/vote", voteHandler.Retract(models.PostTypeAnswer))
+	}
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/anilsoylu/answer-backend/internal/handlers"
+	"github.com/anilsoylu/answer-backend/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupWarningRoutes(router *gin.Engine, warningHandler *handlers.WarningHandler) {
+	admin := router.Group("/api/v1/admin")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	{
+		admin.GET("/warning-reasons", warningHandler.Reasons)
+		admin.GET("/users/:id/warnings", warningHandler.ListForUser)
+		admin.POST("/users/:id/warnings", middleware.BlockImpersonation(), warningHandler.Issue)
+	}
+
+	protected := router.Group("/api/v1/users/me/warnings")
+	protected.Use(middleware.AuthMiddleware())
+	{
+		protected.GET("", warningHandler.ListOwn)
+		// Uyarıyı kullanıcının kendisi onaylamalı
+		protected.POST("/:id/acknowledge", middleware.BlockImpersonation(), warningHandler.Acknowledge)
+	}
+}
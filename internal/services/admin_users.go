@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidSort   = errors.New("invalid sort field")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// Deleted user kinds used to filter ListDeletedUsers
+const (
+	DeletedKindFrozen  = "frozen"
+	DeletedKindDeleted = "deleted"
+)
+
+// ListDeletedUsers soft delete edilmiş (dondurulmuş veya silinmiş) kullanıcıları listeler
+func (s *AuthService) ListDeletedUsers(kind string, page, limit int) ([]models.User, int64, error) {
+	query := s.db.Unscoped().Model(&models.User{}).Where("deleted_at IS NOT NULL")
+
+	// FreezeAccount frozen_date alanını doldurur, DeleteAccount doldurmaz
+	switch kind {
+	case DeletedKindFrozen:
+		query = query.Where("frozen_date IS NOT NULL")
+	case DeletedKindDeleted:
+		query = query.Where("frozen_date IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []models.User
+	if err := query.Order("deleted_at DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// GetDeletedUser soft delete edilmiş tek bir kullanıcıyı getirir
+func (s *AuthService) GetDeletedUser(userID uint) (*models.User, error) {
+	var user models.User
+	if err := s.db.Unscoped().Where("deleted_at IS NOT NULL").First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// RestoreUser dondurulmuş veya silinmiş bir hesabı geri yükler
+func (s *AuthService) RestoreUser(ctx context.Context, userID uint, newUsername, newEmail string, actor *Actor) (*models.User, error) {
+	user, err := s.GetDeletedUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Anonimleştirilmiş hesapların kişisel verileri geri getirilemez
+	if user.AnonymizedAt != nil {
+		return nil, ErrUserAnonymized
+	}
+
+	// Admin, diğer adminleri geri yükleyemez
+	if actor.Role == models.RoleAdmin && (user.Role == models.RoleAdmin || user.Role == models.RoleSuperAdmin) {
+		return nil, ErrForbidden
+	}
+
+	before := userSnapshot(user)
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		renamed := map[string]interface{}{
+			"username_changed": newUsername != "" && newUsername != user.Username,
+			"email_changed":    newEmail != "" && newEmail != user.Email,
+		}
+		if err := s.restoreUser(tx, user, newUsername, newEmail, models.StatusEventRestore, actor); err != nil {
+			return err
+		}
+		return recordUserChange(tx, actor, models.AuditActionUserRestored, before, user, renamed)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("User %d restored by admin %d", user.ID, actor.ID)
+	return user, nil
+}
+
+// userSortColumns maps the sortable fields of ListUsers to SQL expressions
+var userSortColumns = map[string]string{
+	"id":              "id",
+	"username":        "username",
+	"created_at":      "created_at",
+	"last_login_date": "COALESCE(last_login_date, 'epoch'::timestamp)",
+}
+
+// UserListFilter contains the filters of the admin user directory
+type UserListFilter struct {
+	Role            models.UserRole
+	Status          models.UserStatus
+	Search          string
+	CreatedFrom     *time.Time
+	CreatedTo       *time.Time
+	LastLoginFrom   *time.Time
+	LastLoginTo     *time.Time
+	BannedUntilFrom *time.Time
+	BannedUntilTo   *time.Time
+	Sort            string
+	Order           string
+	Cursor          string
+	Limit           int
+}
+
+// userCursor is the position of the last returned row, encoded into an opaque string
+type userCursor struct {
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+// ListUsers admin kullanıcı dizinini filtreler ve cursor ile sayfalar
+func (s *AuthService) ListUsers(filter UserListFilter) ([]models.User, string, error) {
+	sortColumn, ok := userSortColumns[filter.Sort]
+	if !ok {
+		return nil, "", ErrInvalidSort
+	}
+	desc := filter.Order != "asc"
+
+	query := s.db.Model(&models.User{})
+	// Dondurulan hesaplar soft delete edilir, status=frozen filtresi onları da kapsamalı
+	if filter.Status == models.StatusFrozen {
+		query = s.db.Unscoped().Model(&models.User{})
+	}
+
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Search != "" {
+		pattern := "%" + escapeLike(filter.Search) + "%"
+		query = query.Where("(username ILIKE ? OR email ILIKE ?)", pattern, pattern)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at <= ?", *filter.CreatedTo)
+	}
+	if filter.LastLoginFrom != nil {
+		query = query.Where("last_login_date >= ?", *filter.LastLoginFrom)
+	}
+	if filter.LastLoginTo != nil {
+		query = query.Where("last_login_date <= ?", *filter.LastLoginTo)
+	}
+	if filter.BannedUntilFrom != nil {
+		query = query.Where("ban_end_date >= ?", *filter.BannedUntilFrom)
+	}
+	if filter.BannedUntilTo != nil {
+		query = query.Where("ban_end_date <= ?", *filter.BannedUntilTo)
+	}
+
+	if filter.Cursor != "" {
+		cursor, err := decodeUserCursor(filter.Cursor)
+		if err != nil {
+			return nil, "", ErrInvalidCursor
+		}
+
+		value, err := cursorValue(filter.Sort, cursor.Value)
+		if err != nil {
+			return nil, "", ErrInvalidCursor
+		}
+
+		operator := ">"
+		if desc {
+			operator = "<"
+		}
+		query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", sortColumn, operator), value, cursor.ID)
+	}
+
+	direction := "ASC"
+	if desc {
+		direction = "DESC"
+	}
+
+	// Bir sonraki sayfa olup olmadığını anlamak için bir fazla kayıt çek
+	var users []models.User
+	if err := query.Order(fmt.Sprintf("%s %s, id %s", sortColumn, direction, direction)).
+		Limit(filter.Limit + 1).
+		Find(&users).Error; err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if len(users) > filter.Limit {
+		users = users[:filter.Limit]
+		last := users[len(users)-1]
+		nextCursor = encodeUserCursor(userCursor{Value: sortValue(filter.Sort, &last), ID: last.ID})
+	}
+
+	return users, nextCursor, nil
+}
+
+// GetUserDetail returns the full user record, including soft deleted accounts
+func (s *AuthService) GetUserDetail(userID uint) (*models.User, error) {
+	var user models.User
+	if err := s.db.Unscoped().First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func sortValue(sort string, user *models.User) string {
+	switch sort {
+	case "username":
+		return user.Username
+	case "created_at":
+		return user.CreatedAt.Format(time.RFC3339Nano)
+	case "last_login_date":
+		// Sıralama hiç giriş yapmamış kullanıcıları epoch olarak görür, cursor da aynı değeri kullanmalı
+		if user.LastLoginDate.IsZero() {
+			return time.Unix(0, 0).UTC().Format(time.RFC3339Nano)
+		}
+		return user.LastLoginDate.Format(time.RFC3339Nano)
+	default:
+		return strconv.FormatUint(uint64(user.ID), 10)
+	}
+}
+
+func cursorValue(sort, value string) (interface{}, error) {
+	switch sort {
+	case "username":
+		return value, nil
+	case "created_at", "last_login_date":
+		return time.Parse(time.RFC3339Nano, value)
+	default:
+		return strconv.ParseUint(value, 10, 32)
+	}
+}
+
+func encodeUserCursor(cursor userCursor) string {
+	encoded, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+func decodeUserCursor(value string) (*userCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor userCursor
+	if err := json.Unmarshal(decoded, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// escapeLike escapes the wildcard characters of a LIKE pattern
+func escapeLike(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(value)
+}
+
+// GetStatusHistory returns the status transitions of a user, newest first
+func (s *AuthService) GetStatusHistory(userID uint, page, limit int) ([]models.UserStatusHistory, int64, error) {
+	if _, err := s.GetUserDetail(userID); err != nil {
+		return nil, 0, err
+	}
+
+	query := s.db.Model(&models.UserStatusHistory{}).Where("user_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var history []models.UserStatusHistory
+	if err := query.Order("created_at DESC, id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&history).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return history, total, nil
+}
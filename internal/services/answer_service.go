@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrAnswerNotFound    = errors.New("answer not found")
+	ErrQuestionClosed    = errors.New("question is closed")
+	ErrAnswerNotAccepted = errors.New("answer is not the accepted answer")
+)
+
+// answerSortColumns maps the sort modes of List to SQL expressions
+var answerSortColumns = map[string]string{
+	"votes":  "answers.score DESC, answers.created_at ASC, answers.id ASC",
+	"newest": "answers.created_at DESC, answers.id DESC",
+	"oldest": "answers.created_at ASC, answers.id ASC",
+	"active": "answers.updated_at DESC, answers.id DESC",
+}
+
+type AnswerService struct {
+	db *gorm.DB
+}
+
+func NewAnswerService(db *gorm.DB) *AnswerService {
+	return &AnswerService{db: db}
+}
+
+// Create answers an open question on behalf of actor
+func (s *AnswerService) Create(ctx context.Context, questionID uint, body string, actor *Actor) (*models.Answer, error) {
+	author, err := activeAuthor(s.db.WithContext(ctx), actor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	answer := &models.Answer{
+		QuestionID: questionID,
+		AuthorID:   author.ID,
+		Author:     *author,
+		Body:       body,
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Soru aynı anda kapatılırsa cevap eklenmesin
+		question, err := lockQuestion(tx, questionID)
+		if err != nil {
+			return err
+		}
+		if question.Status == models.QuestionClosed {
+			return ErrQuestionClosed
+		}
+
+		if err := tx.Omit("Author").Create(answer).Error; err != nil {
+			return err
+		}
+		return recordAnswerRevision(tx, answer.ID, revisionMeta{EditorID: author.ID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return answer, nil
+}
+
+// Get returns an answer with its author
+func (s *AnswerService) Get(id uint) (*models.Answer, error) {
+	var answer models.Answer
+	if err := s.db.Preload("Author", withDeletedUsers).First(&answer, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAnswerNotFound
+		}
+		return nil, err
+	}
+	return &answer, nil
+}
+
+// List returns a page of the answers of a question together with the accepted answer ID
+func (s *AnswerService) List(questionID uint, sort string, page, limit int) ([]models.Answer, int64, *uint, error) {
+	order, ok := answerSortColumns[sort]
+	if !ok {
+		order = answerSortColumns["votes"]
+	}
+
+	var question models.Question
+	if err := s.db.Select("id", "accepted_answer_id").First(&question, questionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, nil, ErrQuestionNotFound
+		}
+		return nil, 0, nil, err
+	}
+
+	query := s.db.Model(&models.Answer{}).Where("answers.question_id = ?", questionID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, nil, err
+	}
+
+	var answers []models.Answer
+	if err := query.Preload("Author", withDeletedUsers).
+		Order(order).
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&answers).Error; err != nil {
+		return nil, 0, nil, err
+	}
+
+	return answers, total, question.AcceptedAnswerID, nil
+}
+
+// Update edits the body of an answer. Besides the author and admins, users with the
+// edit_posts privilege may edit it. Summary describes the edit in the revision history.
+// The accepted answer ID of the question is returned as well.
+func (s *AnswerService) Update(ctx context.Context, id uint, body, summary string, actor *Actor) (*models.Answer, *uint, error) {
+	answer, err := s.Get(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkEditPermission(s.db.WithContext(ctx), answer.AuthorID, actor); err != nil {
+		return nil, nil, err
+	}
+	if _, err := activeAuthor(s.db.WithContext(ctx), actor.ID); err != nil {
+		return nil, nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return editAnswer(tx, answer.ID, body, revisionMeta{EditorID: actor.ID, Summary: summary})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if answer, err = s.Get(id); err != nil {
+		return nil, nil, err
+	}
+
+	var question models.Question
+	if err := s.db.WithContext(ctx).Select("id", "accepted_answer_id").First(&question, answer.QuestionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrQuestionNotFound
+		}
+		return nil, nil, err
+	}
+
+	return answer, question.AcceptedAnswerID, nil
+}
+
+// Delete soft deletes an answer and withdraws it as accepted answer.
+// Deletions by admins are written to the audit log.
+func (s *AnswerService) Delete(ctx context.Context, id uint, actor *Actor) error {
+	answer, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+	if !canManageContent(answer.AuthorID, actor) {
+		return ErrForbidden
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Kabul işlemiyle yarışmaması için önce soru kilitlenir
+		question, err := lockQuestion(tx, answer.QuestionID)
+		if err != nil && !errors.Is(err, ErrQuestionNotFound) {
+			return err
+		}
+
+		if question != nil && question.AcceptedAnswerID != nil && *question.AcceptedAnswerID == answer.ID {
+			if err := tx.Model(question).Update("accepted_answer_id", nil).Error; err != nil {
+				return err
+			}
+			if err := reverseAcceptReputation(tx, answer.ID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Delete(answer).Error; err != nil {
+			return err
+		}
+
+		if answer.AuthorID == actor.ID {
+			return nil
+		}
+		return recordAuditEvent(tx, actor, auditEntry{
+			Action:       models.AuditActionAnswerDeleted,
+			TargetUserID: answer.AuthorID,
+			Details: map[string]interface{}{
+				"answer_id":   answer.ID,
+				"question_id": answer.QuestionID,
+			},
+		})
+	})
+}
+
+// Accept marks an answer as the accepted answer of its question, replacing the
+// previously accepted one. Only the author of the question may accept.
+func (s *AnswerService) Accept(ctx context.Context, answerID uint, actor *Actor) (*models.Answer, error) {
+	answer, err := s.Get(answerID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		question, err := lockQuestion(tx, answer.QuestionID)
+		if err != nil {
+			return err
+		}
+		if question.AuthorID != actor.ID {
+			return ErrForbidden
+		}
+
+		// Cevap kilit alınmadan önce silinmiş olabilir
+		var count int64
+		if err := tx.Model(&models.Answer{}).Where("id = ?", answer.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrAnswerNotFound
+		}
+
+		if question.AcceptedAnswerID != nil {
+			if *question.AcceptedAnswerID == answer.ID {
+				return nil
+			}
+			if err := reverseAcceptReputation(tx, *question.AcceptedAnswerID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(question).Update("accepted_answer_id", answer.ID).Error; err != nil {
+			return err
+		}
+		return recordAcceptReputation(tx, question, answer)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return answer, nil
+}
+
+// Unaccept withdraws the acceptance of an answer. Only the author of the question may do so.
+func (s *AnswerService) Unaccept(ctx context.Context, answerID uint, actor *Actor) error {
+	answer, err := s.Get(answerID)
+	if err != nil {
+		return err
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		question, err := lockQuestion(tx, answer.QuestionID)
+		if err != nil {
+			return err
+		}
+		if question.AuthorID != actor.ID {
+			return ErrForbidden
+		}
+		if question.AcceptedAnswerID == nil || *question.AcceptedAnswerID != answer.ID {
+			return ErrAnswerNotAccepted
+		}
+
+		if err := tx.Model(question).Update("accepted_answer_id", nil).Error; err != nil {
+			return err
+		}
+		return reverseAcceptReputation(tx, answer.ID)
+	})
+}
+
+// editAnswer changes the body of an answer and records it as a new revision.
+// Suggested edits and rollbacks go through it as well.
+func editAnswer(tx *gorm.DB, id uint, body string, meta revisionMeta) error {
+	// Eşzamanlı düzenlemeler aynı revizyon numarasını almasın
+	if _, err := lockPost(tx, models.PostTypeAnswer, id); err != nil {
+		return err
+	}
+	if err := tx.Model(&models.Answer{}).Where("id = ?", id).Update("body", body).Error; err != nil {
+		return err
+	}
+	return recordAnswerRevision(tx, id, meta)
+}
+
+// lockQuestion loads a question and locks its row until the transaction ends
+func lockQuestion(tx *gorm.DB, id uint) (*models.Question, error) {
+	var question models.Question
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&question, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, err
+	}
+	return &question, nil
+}
@@ -0,0 +1,477 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrApprovalNotFound   = errors.New("approval request not found")
+	ErrApprovalNotPending = errors.New("approval request is not pending")
+	ErrApprovalExpired    = errors.New("approval request has expired")
+	ErrApprovalPending    = errors.New("an approval request for this action is already pending")
+	ErrSelfApproval       = errors.New("approval requests must be approved by a different admin")
+)
+
+const (
+	// defaultApprovalRequestTTL is used when APPROVAL_REQUEST_TTL is not set
+	defaultApprovalRequestTTL = 72 * time.Hour
+
+	// approvalActionsNone disables four-eyes approval when set as APPROVAL_REQUIRED_ACTIONS
+	approvalActionsNone = "none"
+)
+
+// defaultApprovalActions are the actions that need a second admin unless configured otherwise
+var defaultApprovalActions = []string{
+	models.ApprovalActionAssignAdminRole,
+	models.ApprovalActionDeleteAccount,
+	models.ApprovalActionBulkBan,
+	models.ApprovalActionBulkAssignAdmin,
+	models.ApprovalActionInviteAdmin,
+}
+
+// ApprovalService implements four-eyes approval: sensitive admin operations are stored
+// as pending requests and only executed once a different admin approves them.
+type ApprovalService struct {
+	db                *gorm.DB
+	authService       *AuthService
+	bulkService       *BulkService
+	invitationService *InvitationService
+	required          map[string]bool
+	ttl               time.Duration
+}
+
+func NewApprovalService(db *gorm.DB, authService *AuthService, bulkService *BulkService, invitationService *InvitationService) *ApprovalService {
+	actions := defaultApprovalActions
+	if value := os.Getenv("APPROVAL_REQUIRED_ACTIONS"); value != "" {
+		actions = strings.Split(value, ",")
+	}
+
+	required := make(map[string]bool, len(actions))
+	for _, action := range actions {
+		action = strings.TrimSpace(action)
+		if action != "" && action != approvalActionsNone {
+			required[action] = true
+		}
+	}
+
+	return &ApprovalService{
+		db:                db,
+		authService:       authService,
+		bulkService:       bulkService,
+		invitationService: invitationService,
+		required:          required,
+		ttl:               utils.GetEnvDuration("APPROVAL_REQUEST_TTL", defaultApprovalRequestTTL),
+	}
+}
+
+// RoleChangeNeedsApproval reports whether giving role needs a second admin
+func (s *ApprovalService) RoleChangeNeedsApproval(role models.UserRole) bool {
+	return isAdminRole(role) && s.required[models.ApprovalActionAssignAdminRole]
+}
+
+// DeletionNeedsApproval reports whether actor deleting userID needs a second admin.
+// Users deleting their own account never need approval.
+func (s *ApprovalService) DeletionNeedsApproval(userID uint, actor *Actor) bool {
+	return userID != actor.ID && s.required[models.ApprovalActionDeleteAccount]
+}
+
+// InvitationNeedsApproval reports whether inviting someone with role needs a second admin
+func (s *ApprovalService) InvitationNeedsApproval(role models.UserRole) bool {
+	return isAdminRole(role) && s.required[models.ApprovalActionInviteAdmin]
+}
+
+// BulkNeedsApproval returns the approval action for a bulk job, or "" if it can run directly
+func (s *ApprovalService) BulkNeedsApproval(action models.BulkAction, params models.BulkActionParams) string {
+	switch {
+	case action == models.BulkActionBan && s.required[models.ApprovalActionBulkBan]:
+		return models.ApprovalActionBulkBan
+	case action == models.BulkActionRole && isAdminRole(params.Role) && s.required[models.ApprovalActionBulkAssignAdmin]:
+		return models.ApprovalActionBulkAssignAdmin
+	}
+	return ""
+}
+
+// RequestRoleChange creates a pending request for giving an admin role to a user
+func (s *ApprovalService) RequestRoleChange(ctx context.Context, userID uint, role models.UserRole, actor *Actor) (*models.ApprovalRequest, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Onaylanamayacak istekler baştan reddedilir
+	if err := checkRoleChange(user, role, actor); err != nil {
+		return nil, err
+	}
+
+	return s.create(ctx, actor, models.ApprovalActionAssignAdminRole, &userID, models.ApprovalRolePayload{Role: role})
+}
+
+// RequestAccountDeletion creates a pending request for deleting another user's account
+func (s *ApprovalService) RequestAccountDeletion(ctx context.Context, userID uint, actor *Actor) (*models.ApprovalRequest, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkDeletion(user, actor); err != nil {
+		return nil, err
+	}
+
+	return s.create(ctx, actor, models.ApprovalActionDeleteAccount, &userID, struct{}{})
+}
+
+// RequestInvitation creates a pending request for inviting someone with an admin role
+func (s *ApprovalService) RequestInvitation(ctx context.Context, email string, role models.UserRole, actor *Actor) (*models.ApprovalRequest, error) {
+	if err := checkInvitation(role, actor); err != nil {
+		return nil, err
+	}
+
+	return s.create(ctx, actor, models.ApprovalActionInviteAdmin, nil, models.ApprovalInvitationPayload{
+		Email: strings.ToLower(strings.TrimSpace(email)),
+		Role:  role,
+	})
+}
+
+// RequestBulkAction creates a pending request for a bulk job
+func (s *ApprovalService) RequestBulkAction(ctx context.Context, approvalAction string, action models.BulkAction, params models.BulkActionParams, userIDs []uint, actor *Actor) (*models.ApprovalRequest, error) {
+	userIDs = uniqueIDs(userIDs)
+	if len(userIDs) == 0 {
+		return nil, ErrBulkEmpty
+	}
+	if len(userIDs) > MaxBulkRows {
+		return nil, ErrBulkTooLarge
+	}
+
+	return s.create(ctx, actor, approvalAction, nil, models.ApprovalBulkPayload{
+		Action:  action,
+		Params:  params,
+		UserIDs: userIDs,
+	})
+}
+
+func (s *ApprovalService) create(ctx context.Context, actor *Actor, action string, targetUserID *uint, payload interface{}) (*models.ApprovalRequest, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &models.ApprovalRequest{
+		Action:       action,
+		Payload:      string(encoded),
+		TargetUserID: targetUserID,
+		RequestedBy:  actor.ID,
+		Status:       models.ApprovalPending,
+		ExpiresAt:    time.Now().Add(s.ttl),
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if targetUserID != nil {
+			var pending int64
+			if err := tx.Model(&models.ApprovalRequest{}).
+				Where("action = ? AND target_user_id = ? AND status = ? AND expires_at > ?", action, *targetUserID, models.ApprovalPending, time.Now()).
+				Count(&pending).Error; err != nil {
+				return err
+			}
+			if pending > 0 {
+				return ErrApprovalPending
+			}
+		}
+
+		if err := tx.Create(request).Error; err != nil {
+			return err
+		}
+
+		entry := approvalAuditEntry(models.AuditActionApprovalRequested, request)
+		// Davetiye e-postası kişisel veridir, değiştirilemeyen denetim kaydına yazılmaz
+		if action != models.ApprovalActionInviteAdmin {
+			entry.Details["payload"] = json.RawMessage(encoded)
+		}
+		return recordAuditEvent(tx, actor, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// ListRequests onay isteklerini listeler, status boşsa tümü döner
+func (s *ApprovalService) ListRequests(status string, page, limit int) ([]models.ApprovalRequest, int64, error) {
+	query := s.db.Model(&models.ApprovalRequest{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var requests []models.ApprovalRequest
+	if err := query.Order("id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&requests).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return requests, total, nil
+}
+
+// GetRequest tek bir onay isteğini getirir
+func (s *ApprovalService) GetRequest(id uint) (*models.ApprovalRequest, error) {
+	var request models.ApprovalRequest
+	if err := s.db.First(&request, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrApprovalNotFound
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+// Approve approves a pending request and executes it on behalf of the approver.
+// A failed execution does not return an error; the request is marked as failed instead.
+func (s *ApprovalService) Approve(ctx context.Context, id uint, approver *Actor) (*models.ApprovalRequest, error) {
+	request, err := s.GetRequest(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Status != models.ApprovalPending {
+		return nil, ErrApprovalNotPending
+	}
+	if request.RequestedBy == approver.ID {
+		return nil, ErrSelfApproval
+	}
+	if time.Now().After(request.ExpiresAt) {
+		if err := s.expire(ctx, request); err != nil {
+			return nil, err
+		}
+		return nil, ErrApprovalExpired
+	}
+
+	// Onaylayan da işlemi yapmaya yetkili olmalı
+	if err := s.checkApprover(request, approver); err != nil {
+		return nil, err
+	}
+
+	if err := s.decide(ctx, request, approver, models.ApprovalApproved, models.AuditActionApprovalApproved, ""); err != nil {
+		return nil, err
+	}
+
+	result, execErr := s.execute(ctx, request, approver)
+
+	request.Status = models.ApprovalExecuted
+	if execErr != nil {
+		request.Status = models.ApprovalFailed
+		request.ExecutionError = execErr.Error()
+	} else if result != nil {
+		encoded, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		request.Result = string(encoded)
+	}
+	if err := s.db.WithContext(ctx).Model(request).Updates(map[string]interface{}{
+		"status":          request.Status,
+		"execution_error": request.ExecutionError,
+		"result":          request.Result,
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// Reject rejects a pending request. The requester may reject their own request to cancel it.
+func (s *ApprovalService) Reject(ctx context.Context, id uint, reason string, actor *Actor) (*models.ApprovalRequest, error) {
+	request, err := s.GetRequest(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Status != models.ApprovalPending {
+		return nil, ErrApprovalNotPending
+	}
+
+	if err := s.decide(ctx, request, actor, models.ApprovalRejected, models.AuditActionApprovalRejected, reason); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// ExpireStaleRequests süresi dolan bekleyen istekleri expired olarak işaretler
+func (s *ApprovalService) ExpireStaleRequests(ctx context.Context) error {
+	var requests []models.ApprovalRequest
+	if err := s.db.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", models.ApprovalPending, time.Now()).
+		Find(&requests).Error; err != nil {
+		return err
+	}
+
+	for i := range requests {
+		if err := s.expire(ctx, &requests[i]); err != nil && err != ErrApprovalNotPending {
+			return fmt.Errorf("expiring approval request %d: %w", requests[i].ID, err)
+		}
+	}
+
+	if len(requests) > 0 {
+		log.Printf("%d approval requests expired", len(requests))
+	}
+	return nil
+}
+
+func (s *ApprovalService) expire(ctx context.Context, request *models.ApprovalRequest) error {
+	return s.decide(ctx, request, nil, models.ApprovalExpired, models.AuditActionApprovalExpired, "")
+}
+
+// decide moves a pending request to status. The update only matches pending rows,
+// so concurrent approvals and rejections cannot both succeed.
+func (s *ApprovalService) decide(ctx context.Context, request *models.ApprovalRequest, actor *Actor, status models.ApprovalStatus, auditAction, reason string) error {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":          status,
+		"decided_at":      now,
+		"decision_reason": reason,
+	}
+	if actor != nil {
+		updates["decided_by"] = actor.ID
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.ApprovalRequest{}).
+			Where("id = ? AND status = ?", request.ID, models.ApprovalPending).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrApprovalNotPending
+		}
+
+		request.Status = status
+		request.DecidedAt = &now
+		request.DecisionReason = reason
+		if actor != nil {
+			request.DecidedBy = &actor.ID
+		}
+
+		entry := approvalAuditEntry(auditAction, request)
+		if reason != "" {
+			entry.Details["reason"] = reason
+		}
+		return recordAuditEvent(tx, actor, entry)
+	})
+}
+
+// checkApprover verifies that the approver is allowed to perform the requested action
+func (s *ApprovalService) checkApprover(request *models.ApprovalRequest, approver *Actor) error {
+	switch request.Action {
+	case models.ApprovalActionAssignAdminRole:
+		var payload models.ApprovalRolePayload
+		if err := json.Unmarshal([]byte(request.Payload), &payload); err != nil {
+			return err
+		}
+		user, err := s.findUser(*request.TargetUserID)
+		if err != nil {
+			return err
+		}
+		if err := checkRoleChange(user, payload.Role, approver); err != nil {
+			return ErrForbidden
+		}
+	case models.ApprovalActionDeleteAccount:
+		if approver.Role != models.RoleSuperAdmin {
+			return ErrForbidden
+		}
+	case models.ApprovalActionInviteAdmin:
+		var payload models.ApprovalInvitationPayload
+		if err := json.Unmarshal([]byte(request.Payload), &payload); err != nil {
+			return err
+		}
+		return checkInvitation(payload.Role, approver)
+	}
+	return nil
+}
+
+// execute runs the approved action. Audit events of the action itself are
+// recorded by the underlying service methods with the approver as actor.
+func (s *ApprovalService) execute(ctx context.Context, request *models.ApprovalRequest, approver *Actor) (interface{}, error) {
+	switch request.Action {
+	case models.ApprovalActionAssignAdminRole:
+		var payload models.ApprovalRolePayload
+		if err := json.Unmarshal([]byte(request.Payload), &payload); err != nil {
+			return nil, err
+		}
+		return nil, s.authService.UpdateUserRole(ctx, *request.TargetUserID, payload.Role, approver)
+
+	case models.ApprovalActionDeleteAccount:
+		return nil, s.authService.DeleteAccount(ctx, *request.TargetUserID, approver)
+
+	case models.ApprovalActionInviteAdmin:
+		var payload models.ApprovalInvitationPayload
+		if err := json.Unmarshal([]byte(request.Payload), &payload); err != nil {
+			return nil, err
+		}
+		invitation, err := s.invitationService.Create(ctx, payload.Email, payload.Role, approver)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"invitation_id": invitation.ID}, nil
+
+	case models.ApprovalActionBulkBan, models.ApprovalActionBulkAssignAdmin:
+		var payload models.ApprovalBulkPayload
+		if err := json.Unmarshal([]byte(request.Payload), &payload); err != nil {
+			return nil, err
+		}
+		job, err := s.bulkService.Start(ctx, approver, payload.Action, payload.Params, payload.UserIDs, false)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"bulk_job_id": job.ID}, nil
+	}
+
+	return nil, fmt.Errorf("unknown approval action %q", request.Action)
+}
+
+func (s *ApprovalService) findUser(userID uint) (*models.User, error) {
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func approvalAuditEntry(action string, request *models.ApprovalRequest) auditEntry {
+	entry := auditEntry{
+		Action: action,
+		Details: map[string]interface{}{
+			"approval_id":     request.ID,
+			"approval_action": request.Action,
+			"requested_by":    request.RequestedBy,
+		},
+	}
+	if request.TargetUserID != nil {
+		entry.TargetUserID = *request.TargetUserID
+	}
+	return entry
+}
+
+func isAdminRole(role models.UserRole) bool {
+	return role == models.RoleAdmin || role == models.RoleSuperAdmin
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// auditChainLockKey serializes audit writes so that every event links to its predecessor
+const auditChainLockKey = 7301
+
+// Actor identifies who performs an action. It is used for authorization
+// checks and is written to the audit trail.
+type Actor struct {
+	ID          uint
+	Role        models.UserRole
+	IsRootAdmin bool
+	IP          string
+	RequestID   string
+
+	// ImpersonatorID is the admin acting on behalf of the user, 0 when not impersonating
+	ImpersonatorID uint
+}
+
+// auditEntry describes an audit event before it is written
+type auditEntry struct {
+	Action       string
+	TargetUserID uint
+	Details      map[string]interface{}
+	Before       map[string]interface{}
+	After        map[string]interface{}
+}
+
+// recordAuditEvent appends an event to the audit trail using the given transaction,
+// so it is only persisted together with the change it describes
+func recordAuditEvent(tx *gorm.DB, actor *Actor, entry auditEntry) error {
+	event := models.AuditEvent{
+		Action: entry.Action,
+		// Postgres mikro saniye saklar, hash aynı değerle hesaplanmalı
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+
+	// Kullanıcı adına yapılan işlemlerde asıl yapan yönetici de kaydedilir
+	if actor != nil && actor.ImpersonatorID != 0 {
+		details := map[string]interface{}{"impersonated_by": actor.ImpersonatorID}
+		for key, value := range entry.Details {
+			details[key] = value
+		}
+		entry.Details = details
+	}
+
+	var err error
+	if event.Details, err = encodeAuditJSON(entry.Details); err != nil {
+		return err
+	}
+	if event.BeforeState, err = encodeAuditJSON(entry.Before); err != nil {
+		return err
+	}
+	if event.AfterState, err = encodeAuditJSON(entry.After); err != nil {
+		return err
+	}
+
+	if actor != nil {
+		event.ActorID = &actor.ID
+		event.IP = actor.IP
+		event.RequestID = actor.RequestID
+	}
+	if entry.TargetUserID != 0 {
+		event.TargetUserID = &entry.TargetUserID
+	}
+
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", auditChainLockKey).Error; err != nil {
+		return err
+	}
+
+	var last []string
+	if err := tx.Model(&models.AuditEvent{}).
+		Where("hash IS NOT NULL").
+		Order("id DESC").
+		Limit(1).
+		Pluck("hash", &last).Error; err != nil {
+		return err
+	}
+	if len(last) > 0 {
+		event.PrevHash = last[0]
+	}
+	event.Hash = computeAuditHash(event.PrevHash, &event)
+
+	return tx.Create(&event).Error
+}
+
+// recordUserChange records a change on user with the fields that differ from before
+func recordUserChange(tx *gorm.DB, actor *Actor, action string, before map[string]interface{}, user *models.User, details map[string]interface{}) error {
+	b, a := diffSnapshots(before, userSnapshot(user))
+	return recordAuditEvent(tx, actor, auditEntry{
+		Action:       action,
+		TargetUserID: user.ID,
+		Details:      details,
+		Before:       b,
+		After:        a,
+	})
+}
+
+// userSnapshot captures the audited fields of a user. Username, email and the free-text
+// ban and freeze reasons are left out on purpose: audit events are immutable and must
+// not keep personal data after erasure, so only the presence of a reason is recorded.
+func userSnapshot(user *models.User) map[string]interface{} {
+	return map[string]interface{}{
+		"status":            string(user.Status),
+		"role":              string(user.Role),
+		"is_root_admin":     user.IsRootAdmin,
+		"has_ban_reason":    user.BanReason != "",
+		"ban_end_date":      formatAuditTime(user.BanEndDate),
+		"has_frozen_reason": user.FrozenReason != "",
+		"frozen_date":       formatAuditTime(user.FrozenDate),
+		"deleted":           user.DeletedAt.Valid,
+	}
+}
+
+// diffSnapshots returns only the fields that changed between two snapshots
+func diffSnapshots(before, after map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	b := map[string]interface{}{}
+	a := map[string]interface{}{}
+	for key, value := range after {
+		if !reflect.DeepEqual(before[key], value) {
+			b[key] = before[key]
+			a[key] = value
+		}
+	}
+	return b, a
+}
+
+// computeAuditHash hashes the event content together with the hash of the previous event
+func computeAuditHash(prevHash string, event *models.AuditEvent) string {
+	fields := []string{
+		prevHash,
+		FormatOptionalID(event.ActorID),
+		FormatOptionalID(event.TargetUserID),
+		event.Action,
+		event.Details,
+		event.BeforeState,
+		event.AfterState,
+		event.IP,
+		event.RequestID,
+		strconv.FormatInt(event.CreatedAt.UnixMicro(), 10),
+	}
+
+	h := sha256.New()
+	for _, field := range fields {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func encodeAuditJSON(value map[string]interface{}) (string, error) {
+	if len(value) == 0 {
+		return "", nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+func formatAuditTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// FormatOptionalID formats an optional user ID for hashing and CSV export, empty when nil
+func FormatOptionalID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*id), 10)
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrAuditChainBroken = errors.New("audit chain is broken")
+
+const auditVerifyBatchSize = 500
+
+// AuditFilter contains the filters of the audit log
+type AuditFilter struct {
+	ActorID      uint
+	TargetUserID uint
+	Action       string
+	RequestID    string
+	From         *time.Time
+	To           *time.Time
+	BeforeID     uint
+	Limit        int
+}
+
+// AuditVerification is the result of verifying the audit hash chain
+type AuditVerification struct {
+	Valid         bool  `json:"valid"`
+	CheckedEvents int   `json:"checked_events"`
+	BrokenAtID    *uint `json:"broken_at_id,omitempty"`
+}
+
+type AuditService struct {
+	db *gorm.DB
+}
+
+func NewAuditService(db *gorm.DB) *AuditService {
+	return &AuditService{db: db}
+}
+
+// List returns audit events newest first, paginated by the ID of the last seen event
+func (s *AuditService) List(filter AuditFilter) ([]models.AuditEvent, error) {
+	query := s.filtered(filter)
+	if filter.BeforeID != 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+
+	var events []models.AuditEvent
+	if err := query.Order("id DESC").Limit(filter.Limit).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// Export streams all events matching the filter in chronological order
+func (s *AuditService) Export(ctx context.Context, filter AuditFilter, write func(events []models.AuditEvent) error) error {
+	var events []models.AuditEvent
+	return s.filtered(filter).WithContext(ctx).
+		FindInBatches(&events, auditVerifyBatchSize, func(tx *gorm.DB, batch int) error {
+			return write(events)
+		}).Error
+}
+
+// Verify recomputes the hash chain and reports the first event that does not match.
+// Events written before the hash chain was introduced have no hash and are skipped.
+func (s *AuditService) Verify(ctx context.Context) (*AuditVerification, error) {
+	result := &AuditVerification{Valid: true}
+	prevHash := ""
+
+	var events []models.AuditEvent
+	err := s.db.WithContext(ctx).
+		Where("hash IS NOT NULL").
+		FindInBatches(&events, auditVerifyBatchSize, func(tx *gorm.DB, batch int) error {
+			for i := range events {
+				event := &events[i]
+				if event.PrevHash != prevHash || computeAuditHash(event.PrevHash, event) != event.Hash {
+					result.Valid = false
+					result.BrokenAtID = &event.ID
+					return ErrAuditChainBroken
+				}
+				prevHash = event.Hash
+				result.CheckedEvents++
+			}
+			return nil
+		}).Error
+	if err != nil && !errors.Is(err, ErrAuditChainBroken) {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *AuditService) filtered(filter AuditFilter) *gorm.DB {
+	query := s.db.Model(&models.AuditEvent{})
+
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.TargetUserID != 0 {
+		query = query.Where("target_user_id = ?", filter.TargetUserID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+
+	return query
+}
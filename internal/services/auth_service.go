@@ -5,9 +5,11 @@ import (
 	"errors"
 	"log"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
@@ -24,8 +26,14 @@ var (
 	ErrDuplicateEntry     = errors.New("duplicate entry")
 	ErrUnauthorized       = errors.New("unauthorized")
 	ErrForbidden          = errors.New("forbidden")
+	ErrReactivationAvailable = errors.New("this account is frozen and can be reactivated")
+	ErrUserAnonymized     = errors.New("this account has been anonymized and cannot be restored")
+	ErrUserNotBanned      = errors.New("user is not banned")
 )
 
+// defaultReactivationGracePeriod is used when ACCOUNT_REACTIVATION_GRACE_PERIOD is not set
+const defaultReactivationGracePeriod = 30 * 24 * time.Hour
+
 type AuthService struct {
 	db *gorm.DB
 }
@@ -78,10 +86,18 @@ func (s *AuthService) Register(user *models.User) error {
 	return nil
 }
 
-func (s *AuthService) Login(identifier, password string) (*models.User, error) {
+func (s *AuthService) Login(identifier, password, ip, requestID string) (*models.User, error) {
 	var user models.User
 	if err := s.db.Where("email = ? OR username = ?", identifier, identifier).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Dondurulmuş hesaplar soft delete edildiği için yukarıdaki sorguda görünmez
+			frozen, err := s.findFrozenAccount(identifier, password)
+			if err != nil {
+				return nil, err
+			}
+			if frozen != nil {
+				return nil, ErrReactivationAvailable
+			}
 			return nil, ErrInvalidCredentials
 		}
 		return nil, err
@@ -92,6 +108,11 @@ func (s *AuthService) Login(identifier, password string) (*models.User, error) {
 		return nil, ErrInvalidCredentials
 	}
 
+	// Pasif hesaplar bir yönetici tarafından pasifleştirilmedikçe girişle yeniden etkinleşir
+	if user.Status == models.StatusPassive {
+		return s.loginPassiveUser(&user, ip, requestID)
+	}
+
 	// Check if user is active
 	if user.Status != models.StatusActive {
 		return nil, ErrUserNotActive
@@ -106,7 +127,179 @@ func (s *AuthService) Login(identifier, password string) (*models.User, error) {
 	return &user, nil
 }
 
-func (s *AuthService) UpdateUserRole(userID uint, newRole models.UserRole, requester *models.User) error {
+// loginPassiveUser reactivates a user who was made passive for inactivity or by themselves
+func (s *AuthService) loginPassiveUser(user *models.User, ip, requestID string) (*models.User, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := activatePassiveUser(tx, user, &Actor{ID: user.ID, Role: user.Role, IP: ip, RequestID: requestID}, "login"); err != nil {
+			// Yönetici tarafından pasifleştirilen hesaplar giriş ile etkinleşmez
+			if errors.Is(err, ErrInvalidStatusTransition) {
+				return ErrUserNotActive
+			}
+			return err
+		}
+
+		user.LastLoginDate = time.Now()
+		return tx.Model(user).Update("last_login_date", user.LastLoginDate).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ReactivateAccount reactivates a frozen account whose grace period has not expired yet.
+// newUsername and newEmail replace the stored values when they were taken by another
+// account in the meantime.
+func (s *AuthService) ReactivateAccount(identifier, password, newUsername, newEmail, ip, requestID string) (*models.User, error) {
+	user, err := s.findFrozenAccount(identifier, password)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	before := userSnapshot(user)
+	actor := &Actor{ID: user.ID, Role: user.Role, IP: ip, RequestID: requestID}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.restoreUser(tx, user, newUsername, newEmail, models.StatusEventReactivate, actor); err != nil {
+			return err
+		}
+
+		// Yeniden etkinleştirme aynı zamanda bir giriştir
+		user.LastLoginDate = time.Now()
+		if err := tx.Model(user).Update("last_login_date", user.LastLoginDate).Error; err != nil {
+			return err
+		}
+
+		return recordUserChange(tx, actor, models.AuditActionUserReactivated, before, user, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Frozen account reactivated: %s (%s)", user.Username, user.Email)
+	return user, nil
+}
+
+// findFrozenAccount returns the frozen account matching the credentials, or nil if there is none.
+// ErrUserFrozen is returned once the reactivation grace period is over.
+func (s *AuthService) findFrozenAccount(identifier, password string) (*models.User, error) {
+	// Aynı kullanıcı adıyla birden fazla dondurulmuş hesap olabilir, en yenisinden başla
+	var candidates []models.User
+	if err := s.db.Unscoped().
+		Where("(email = ? OR username = ?) AND deleted_at IS NOT NULL AND status = ? AND frozen_date IS NOT NULL",
+			identifier, identifier, models.StatusFrozen).
+		Order("frozen_date DESC").
+		Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range candidates {
+		user := &candidates[i]
+		if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+			continue
+		}
+
+		gracePeriod := utils.GetEnvDuration("ACCOUNT_REACTIVATION_GRACE_PERIOD", defaultReactivationGracePeriod)
+		if time.Since(*user.FrozenDate) > gracePeriod {
+			return nil, ErrUserFrozen
+		}
+		return user, nil
+	}
+
+	return nil, nil
+}
+
+// restoreUser brings a soft deleted user back as an active account.
+// Username and email must not collide with an active account (see idx_username_active / idx_email_active).
+func (s *AuthService) restoreUser(tx *gorm.DB, user *models.User, newUsername, newEmail string, event models.StatusEvent, actor *Actor) error {
+	if err := transitionStatus(tx, user, event, actor, ""); err != nil {
+		return err
+	}
+
+	username := user.Username
+	if newUsername != "" {
+		username = newUsername
+	}
+	email := user.Email
+	if newEmail != "" {
+		email = newEmail
+	}
+
+	var count int64
+	if err := tx.Model(&models.User{}).
+		Where("username = ? AND id <> ? AND status <> ?", username, user.ID, models.StatusFrozen).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrUsernameTaken
+	}
+
+	if err := tx.Model(&models.User{}).
+		Where("email = ? AND id <> ? AND status <> ?", email, user.ID, models.StatusFrozen).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrEmailTaken
+	}
+
+	err := tx.Unscoped().Model(user).Updates(map[string]interface{}{
+		"username":      username,
+		"email":         email,
+		"status":        user.Status,
+		"frozen_reason": "",
+		"frozen_date":   nil,
+		"deleted_at":    nil,
+	}).Error
+	if err != nil {
+		// Kontrol ile güncelleme arasında başka bir kayıt aynı değeri almış olabilir
+		if strings.Contains(err.Error(), "idx_username_active") {
+			return ErrUsernameTaken
+		}
+		if strings.Contains(err.Error(), "idx_email_active") {
+			return ErrEmailTaken
+		}
+		return err
+	}
+
+	user.Username = username
+	user.Email = email
+	user.FrozenReason = ""
+	user.FrozenDate = nil
+	user.DeletedAt = gorm.DeletedAt{}
+	return nil
+}
+
+func (s *AuthService) UpdateUserRole(ctx context.Context, userID uint, newRole models.UserRole, actor *Actor) error {
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	if err := checkRoleChange(&user, newRole, actor); err != nil {
+		return err
+	}
+
+	before := userSnapshot(&user)
+	user.Role = newRole
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&user).Error; err != nil {
+			return err
+		}
+		return recordUserChange(tx, actor, models.AuditActionRoleChanged, before, &user, nil)
+	})
+}
+
+func (s *AuthService) UpdateUserStatus(ctx context.Context, userID uint, newStatus models.UserStatus, actor *Actor) error {
 	var user models.User
 	if err := s.db.First(&user, userID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -115,7 +308,36 @@ func (s *AuthService) UpdateUserRole(userID uint, newRole models.UserRole, reque
 		return err
 	}
 
-	// Check permissions and role hierarchy
+	// Ban, dondurma ve silme kendi endpoint'leri üzerinden yapılır
+	event, ok := statusEventFor(newStatus)
+	if !ok {
+		return ErrInvalidStatusTransition
+	}
+
+	if err := checkStatusChange(&user, newStatus, actor.Role); err != nil {
+		return err
+	}
+
+	before := userSnapshot(&user)
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := transitionStatus(tx, &user, event, actor, ""); err != nil {
+			return err
+		}
+		if err := tx.Save(&user).Error; err != nil {
+			return err
+		}
+		return recordUserChange(tx, actor, models.AuditActionStatusChanged, before, &user, nil)
+	})
+}
+
+// checkRoleChange verifies the role hierarchy rules for giving newRole to user
+func checkRoleChange(user *models.User, newRole models.UserRole, requester *Actor) error {
+	// Root admin yetkisi önce kaldırılmalı, aksi halde son root admin rolünü kaybedebilir
+	if user.IsRootAdmin {
+		return errors.New("root admin's role cannot be changed")
+	}
+
 	if user.Role == models.RoleSuperAdmin && !requester.IsRootAdmin {
 		return errors.New("only root admin can change SUPER_ADMIN's role")
 	}
@@ -130,24 +352,11 @@ func (s *AuthService) UpdateUserRole(userID uint, newRole models.UserRole, reque
 		}
 	}
 
-	user.Role = newRole
-	if err := s.db.Save(&user).Error; err != nil {
-		return err
-	}
-
 	return nil
 }
 
-func (s *AuthService) UpdateUserStatus(userID uint, newStatus models.UserStatus, requesterRole models.UserRole) error {
-	var user models.User
-	if err := s.db.First(&user, userID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrUserNotFound
-		}
-		return err
-	}
-
-	// Check permissions
+// checkStatusChange verifies that the requester may set newStatus on user
+func checkStatusChange(user *models.User, newStatus models.UserStatus, requesterRole models.UserRole) error {
 	if user.Role == models.RoleSuperAdmin {
 		return ErrForbidden
 	}
@@ -167,9 +376,23 @@ func (s *AuthService) UpdateUserStatus(userID uint, newStatus models.UserStatus,
 		return ErrUnauthorized
 	}
 
-	user.Status = newStatus
-	if err := s.db.Save(&user).Error; err != nil {
-		return err
+	return nil
+}
+
+// checkBan verifies that the requester may ban or unban user
+func checkBan(user *models.User, requesterRole models.UserRole) error {
+	if requesterRole != models.RoleAdmin && requesterRole != models.RoleSuperAdmin {
+		return ErrUnauthorized
+	}
+
+	// Admin, diğer adminleri ve super adminleri banlayamaz
+	if requesterRole == models.RoleAdmin && (user.Role == models.RoleAdmin || user.Role == models.RoleSuperAdmin) {
+		return ErrForbidden
+	}
+
+	// Super admin'i kimse banlayamaz
+	if user.Role == models.RoleSuperAdmin {
+		return ErrForbidden
 	}
 
 	return nil
@@ -281,7 +504,7 @@ func (s *AuthService) UpdatePassword(ctx context.Context, userID uint, req model
 }
 
 // BanUser kullanıcıyı banlar
-func (s *AuthService) BanUser(ctx context.Context, userID uint, banReason, banDuration string, requesterRole models.UserRole) error {
+func (s *AuthService) BanUser(ctx context.Context, userID uint, banReason, banDuration string, actor *Actor) error {
 	var user models.User
 	if err := s.db.First(&user, userID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -290,53 +513,89 @@ func (s *AuthService) BanUser(ctx context.Context, userID uint, banReason, banDu
 		return err
 	}
 
-	// Yetki kontrolü
-	if requesterRole != models.RoleAdmin && requesterRole != models.RoleSuperAdmin {
-		return ErrUnauthorized
+	if err := checkBan(&user, actor.Role); err != nil {
+		return err
 	}
 
-	// Admin, diğer adminleri ve super adminleri banlayamaz
-	if requesterRole == models.RoleAdmin && (user.Role == models.RoleAdmin || user.Role == models.RoleSuperAdmin) {
-		return ErrForbidden
-	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return applyBan(tx, &user, banReason, banDuration, actor, nil)
+	})
+}
 
-	// Super admin'i kimse banlayamaz
-	if user.Role == models.RoleSuperAdmin {
-		return ErrForbidden
+// applyBan bans user inside tx. details are merged into the audit event,
+// e.g. to link an automatic ban to the warning that triggered it.
+func applyBan(tx *gorm.DB, user *models.User, banReason, banDuration string, actor *Actor, details map[string]interface{}) error {
+	before := userSnapshot(user)
+
+	// Kullanıcıyı banla
+	user.BanReason = banReason
+	user.BanEndDate = banEndDate(banDuration, time.Now())
+
+	if err := transitionStatus(tx, user, models.StatusEventBan, actor, banReason); err != nil {
+		return err
+	}
+	if err := tx.Save(user).Error; err != nil {
+		return err
 	}
 
-	// Ban süresini hesapla
-	var banEndDate *time.Time
-	now := time.Now()
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	details["ban_duration"] = banDuration
+	return recordUserChange(tx, actor, models.AuditActionUserBanned, before, user, details)
+}
 
+// banEndDate ban süresini hesaplar, kalıcı banlar için nil döner
+func banEndDate(banDuration string, now time.Time) *time.Time {
+	var end time.Time
 	switch banDuration {
 	case "1_day":
-		end := now.Add(24 * time.Hour)
-		banEndDate = &end
+		end = now.Add(24 * time.Hour)
 	case "1_week":
-		end := now.Add(7 * 24 * time.Hour)
-		banEndDate = &end
+		end = now.Add(7 * 24 * time.Hour)
 	case "1_month":
-		end := now.AddDate(0, 1, 0)
-		banEndDate = &end
-	case "permanent":
-		banEndDate = nil
+		end = now.AddDate(0, 1, 0)
+	default:
+		return nil
 	}
+	return &end
+}
 
-	// Kullanıcıyı banla
-	user.Status = models.StatusBanned
-	user.BanReason = banReason
-	user.BanEndDate = banEndDate
+// UnbanUser kullanıcının banını kaldırır
+func (s *AuthService) UnbanUser(ctx context.Context, userID uint, actor *Actor) error {
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
 
-	if err := s.db.Save(&user).Error; err != nil {
+	if err := checkBan(&user, actor.Role); err != nil {
 		return err
 	}
 
-	return nil
+	if user.Status != models.StatusBanned {
+		return ErrUserNotBanned
+	}
+
+	before := userSnapshot(&user)
+	user.BanReason = ""
+	user.BanEndDate = nil
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := transitionStatus(tx, &user, models.StatusEventUnban, actor, ""); err != nil {
+			return err
+		}
+		if err := tx.Save(&user).Error; err != nil {
+			return err
+		}
+		return recordUserChange(tx, actor, models.AuditActionUserUnbanned, before, &user, nil)
+	})
 }
 
 // FreezeAccount hesabı dondurur (soft delete)
-func (s *AuthService) FreezeAccount(ctx context.Context, userID uint, freezeReason string, requesterRole models.UserRole) error {
+func (s *AuthService) FreezeAccount(ctx context.Context, userID uint, freezeReason string, actor *Actor) error {
 	var user models.User
 	if err := s.db.First(&user, userID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -355,27 +614,34 @@ func (s *AuthService) FreezeAccount(ctx context.Context, userID uint, freezeReas
 		return errors.New("freeze reason is required")
 	}
 
-	// Soft delete işlemi
-	if err := s.db.Delete(&user).Error; err != nil {
-		return err
-	}
+	before := userSnapshot(&user)
 
-	// Dondurma bilgilerini güncelle
-	user.Status = models.StatusFrozen
-	user.FrozenReason = freezeReason
-	now := time.Now()
-	user.FrozenDate = &now
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := transitionStatus(tx, &user, models.StatusEventFreeze, actor, freezeReason); err != nil {
+			return err
+		}
 
-	// Soft delete edilmiş kaydı güncelle
-	if err := s.db.Unscoped().Save(&user).Error; err != nil {
-		return err
-	}
+		// Soft delete işlemi
+		if err := tx.Delete(&user).Error; err != nil {
+			return err
+		}
 
-	return nil
+		// Dondurma bilgilerini güncelle
+		user.FrozenReason = freezeReason
+		now := time.Now()
+		user.FrozenDate = &now
+
+		// Soft delete edilmiş kaydı güncelle
+		if err := tx.Unscoped().Save(&user).Error; err != nil {
+			return err
+		}
+
+		return recordUserChange(tx, actor, models.AuditActionUserFrozen, before, &user, nil)
+	})
 }
 
 // DeleteAccount hesabı siler (soft delete)
-func (s *AuthService) DeleteAccount(ctx context.Context, userID uint, requesterID uint, requesterRole models.UserRole) error {
+func (s *AuthService) DeleteAccount(ctx context.Context, userID uint, actor *Actor) error {
 	var user models.User
 	if err := s.db.First(&user, userID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -384,8 +650,35 @@ func (s *AuthService) DeleteAccount(ctx context.Context, userID uint, requesterI
 		return err
 	}
 
+	if err := checkDeletion(&user, actor); err != nil {
+		return err
+	}
+
+	before := userSnapshot(&user)
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := transitionStatus(tx, &user, models.StatusEventDelete, actor, ""); err != nil {
+			return err
+		}
+
+		// Kullanıcıyı soft delete yap
+		if err := tx.Delete(&user).Error; err != nil {
+			return err
+		}
+
+		// Status'u güncelle
+		if err := tx.Unscoped().Save(&user).Error; err != nil {
+			return err
+		}
+
+		return recordUserChange(tx, actor, models.AuditActionUserDeleted, before, &user, nil)
+	})
+}
+
+// checkDeletion verifies that the requester may delete user's account
+func checkDeletion(user *models.User, requester *Actor) error {
 	// Kullanıcı kendi hesabını silebilir veya SUPER_ADMIN başka hesapları silebilir
-	if userID != requesterID && requesterRole != models.RoleSuperAdmin {
+	if user.ID != requester.ID && requester.Role != models.RoleSuperAdmin {
 		return ErrUnauthorized
 	}
 
@@ -394,21 +687,10 @@ func (s *AuthService) DeleteAccount(ctx context.Context, userID uint, requesterI
 		return ErrForbidden
 	}
 
-	// Kullanıcıyı soft delete yap
-	if err := s.db.Delete(&user).Error; err != nil {
-		return err
-	}
-
-	// Status'u güncelle
-	user.Status = models.StatusFrozen
-	if err := s.db.Unscoped().Save(&user).Error; err != nil {
-		return err
-	}
-
 	return nil
 }
 
-func (s *AuthService) AdminLogin(identifier, password string) (*models.User, error) {
+func (s *AuthService) AdminLogin(identifier, password, ip, requestID string) (*models.User, error) {
 	var user models.User
 	if err := s.db.Where("email = ? OR username = ?", identifier, identifier).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -427,6 +709,11 @@ func (s *AuthService) AdminLogin(identifier, password string) (*models.User, err
 		return nil, ErrUnauthorized
 	}
 
+	// Pasif hesaplar bir yönetici tarafından pasifleştirilmedikçe girişle yeniden etkinleşir
+	if user.Status == models.StatusPassive {
+		return s.loginPassiveUser(&user, ip, requestID)
+	}
+
 	// Check if user is active
 	if user.Status != models.StatusActive {
 		return nil, ErrUserNotActive
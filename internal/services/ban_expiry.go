@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+const banExpiryBatchSize = 100
+
+// errBanNoLongerExpired rolls back an unban when the ban was changed meanwhile
+var errBanNoLongerExpired = errors.New("ban was changed after being selected for expiry")
+
+// LiftExpiredBans unbans users whose temporary ban is over. Permanent bans have no
+// end date and are never lifted automatically.
+func (s *AuthService) LiftExpiredBans(ctx context.Context) error {
+	var lastID uint
+	for {
+		now := time.Now()
+
+		var users []models.User
+		if err := s.db.WithContext(ctx).
+			Where("id > ? AND status = ? AND ban_end_date IS NOT NULL AND ban_end_date <= ?", lastID, models.StatusBanned, now).
+			Order("id").
+			Limit(banExpiryBatchSize).
+			Find(&users).Error; err != nil {
+			return err
+		}
+
+		for i := range users {
+			if err := s.liftBan(ctx, &users[i], now); err != nil {
+				log.Printf("Failed to lift expired ban of user %d: %v", users[i].ID, err)
+			}
+		}
+
+		if len(users) < banExpiryBatchSize {
+			return nil
+		}
+		lastID = users[len(users)-1].ID
+	}
+}
+
+func (s *AuthService) liftBan(ctx context.Context, user *models.User, now time.Time) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before := userSnapshot(user)
+
+		// Sistem tarafından yapılan işlem, aktör yok
+		if err := transitionStatus(tx, user, models.StatusEventUnban, nil, "ban_expired"); err != nil {
+			return err
+		}
+		user.BanReason = ""
+		user.BanEndDate = nil
+
+		// Bu arada yeniden banlanan kullanıcının yeni banı kaldırılmaz
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND status = ? AND ban_end_date <= ?", user.ID, models.StatusBanned, now).
+			Updates(map[string]interface{}{
+				"status":       user.Status,
+				"ban_reason":   "",
+				"ban_end_date": nil,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errBanNoLongerExpired
+		}
+
+		return recordUserChange(tx, nil, models.AuditActionUserUnbanned, before, user, map[string]interface{}{
+			"reason": "ban_expired",
+		})
+	})
+	if errors.Is(err, errBanNoLongerExpired) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,320 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBulkEmpty       = errors.New("no user IDs given")
+	ErrBulkTooLarge    = errors.New("too many user IDs in a single bulk action")
+	ErrBulkJobNotFound = errors.New("bulk job not found")
+)
+
+const (
+	// MaxBulkRows is the maximum number of users in a single bulk action
+	MaxBulkRows = 10000
+
+	// defaultBulkSyncLimit is used when BULK_SYNC_LIMIT is not set, larger batches run in the background
+	defaultBulkSyncLimit = 100
+
+	bulkProgressInterval = 50
+)
+
+// Bulk row results
+const (
+	BulkResultApplied    = "applied"
+	BulkResultWouldApply = "would_apply"
+	BulkResultSkipped    = "skipped"
+	BulkResultFailed     = "failed"
+)
+
+type BulkService struct {
+	db          *gorm.DB
+	authService *AuthService
+	syncLimit   int
+}
+
+func NewBulkService(db *gorm.DB, authService *AuthService, syncLimit int) *BulkService {
+	if syncLimit <= 0 {
+		syncLimit = defaultBulkSyncLimit
+	}
+	return &BulkService{
+		db:          db,
+		authService: authService,
+		syncLimit:   syncLimit,
+	}
+}
+
+// Start creates a bulk job for the given users. Small batches are processed before
+// returning; larger ones run in the background and the job is returned as pending.
+func (s *BulkService) Start(ctx context.Context, actor *Actor, action models.BulkAction, params models.BulkActionParams, userIDs []uint, dryRun bool) (*models.BulkJob, error) {
+	userIDs = uniqueIDs(userIDs)
+	if len(userIDs) == 0 {
+		return nil, ErrBulkEmpty
+	}
+	if len(userIDs) > MaxBulkRows {
+		return nil, ErrBulkTooLarge
+	}
+
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &models.BulkJob{
+		ActorID: actor.ID,
+		Action:  action,
+		Params:  string(encoded),
+		DryRun:  dryRun,
+		Status:  models.BulkJobPending,
+		Total:   len(userIDs),
+		Results: "[]",
+	}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(job).Error; err != nil {
+			return err
+		}
+		if dryRun {
+			return nil
+		}
+		// Satırlar ayrıca kendi denetim kayıtlarını üretir
+		return recordAuditEvent(tx, actor, auditEntry{
+			Action: models.AuditActionBulkJobStarted,
+			Details: map[string]interface{}{
+				"job_id": job.ID,
+				"action": action,
+				"params": params,
+				"total":  job.Total,
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(userIDs) > s.syncLimit {
+		go s.run(context.Background(), job, actor, params, userIDs)
+		return job, nil
+	}
+
+	s.run(ctx, job, actor, params, userIDs)
+	return job, nil
+}
+
+// GetJob returns a bulk job with its per-row results
+func (s *BulkService) GetJob(jobID uint) (*models.BulkJob, []models.BulkRowResult, error) {
+	var job models.BulkJob
+	if err := s.db.First(&job, jobID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrBulkJobNotFound
+		}
+		return nil, nil, err
+	}
+
+	var results []models.BulkRowResult
+	if err := json.Unmarshal([]byte(job.Results), &results); err != nil {
+		return nil, nil, err
+	}
+
+	return &job, results, nil
+}
+
+// FailInterruptedJobs marks jobs that were pending or running when the server stopped
+// as failed. Jobs run in the process that started them, so none of them can still be
+// running at startup. Their results keep the rows processed before the interruption.
+func (s *BulkService) FailInterruptedJobs(ctx context.Context) error {
+	result := s.db.WithContext(ctx).Model(&models.BulkJob{}).
+		Where("status IN ?", []models.BulkJobStatus{models.BulkJobPending, models.BulkJobRunning}).
+		Updates(map[string]interface{}{
+			"status":       models.BulkJobFailed,
+			"completed_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("Marked %d interrupted bulk jobs as failed", result.RowsAffected)
+	}
+	return nil
+}
+
+func (s *BulkService) run(ctx context.Context, job *models.BulkJob, actor *Actor, params models.BulkActionParams, userIDs []uint) {
+	job.Status = models.BulkJobRunning
+	s.db.Model(job).Update("status", job.Status)
+
+	results := make([]models.BulkRowResult, 0, len(userIDs))
+	for i, userID := range userIDs {
+		result := s.processRow(ctx, job, actor, params, userID)
+		results = append(results, result)
+
+		job.Processed++
+		switch result.Result {
+		case BulkResultApplied, BulkResultWouldApply:
+			job.Succeeded++
+		case BulkResultFailed:
+			job.Failed++
+		}
+
+		// Sonuçlar her adımda kaydedilir, yarıda kalan işin işlenen satırları kaybolmasın
+		if (i+1)%bulkProgressInterval == 0 && i+1 < len(userIDs) {
+			if err := s.saveProgress(job, results, nil); err != nil {
+				log.Printf("Failed to save progress of bulk job %d: %v", job.ID, err)
+			}
+		}
+	}
+
+	job.Status = models.BulkJobCompleted
+	now := time.Now()
+	job.CompletedAt = &now
+	if err := s.saveProgress(job, results, map[string]interface{}{
+		"status":       job.Status,
+		"completed_at": now,
+	}); err != nil {
+		log.Printf("Failed to save bulk job %d: %v", job.ID, err)
+		s.db.Model(job).Updates(map[string]interface{}{
+			"status":       models.BulkJobFailed,
+			"completed_at": now,
+		})
+	}
+}
+
+// saveProgress stores the counters and the results processed so far together with extra
+func (s *BulkService) saveProgress(job *models.BulkJob, results []models.BulkRowResult, extra map[string]interface{}) error {
+	encoded, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	job.Results = string(encoded)
+
+	changes := map[string]interface{}{
+		"processed": job.Processed,
+		"succeeded": job.Succeeded,
+		"failed":    job.Failed,
+		"results":   job.Results,
+	}
+	for key, value := range extra {
+		changes[key] = value
+	}
+	return s.db.Model(job).Updates(changes).Error
+}
+
+func (s *BulkService) processRow(ctx context.Context, job *models.BulkJob, actor *Actor, params models.BulkActionParams, userID uint) models.BulkRowResult {
+	result := models.BulkRowResult{UserID: userID}
+
+	if userID == actor.ID {
+		result.Result = BulkResultFailed
+		result.Error = "bulk actions cannot be applied to your own account"
+		return result
+	}
+
+	var user models.User
+	if err := s.db.WithContext(ctx).First(&user, userID).Error; err != nil {
+		result.Result = BulkResultFailed
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			result.Error = ErrUserNotFound.Error()
+		} else {
+			result.Error = err.Error()
+		}
+		return result
+	}
+
+	var check error
+	switch job.Action {
+	case models.BulkActionBan:
+		result.Before, result.After = string(user.Status), string(models.StatusBanned)
+		check = checkBan(&user, actor.Role)
+	case models.BulkActionUnban:
+		result.Before, result.After = string(user.Status), string(models.StatusActive)
+		check = checkBan(&user, actor.Role)
+		if check == nil && user.Status != models.StatusBanned {
+			check = ErrUserNotBanned
+		}
+	case models.BulkActionStatus:
+		result.Before, result.After = string(user.Status), string(params.Status)
+		check = checkStatusChange(&user, params.Status, actor.Role)
+	case models.BulkActionRole:
+		result.Before, result.After = string(user.Role), string(params.Role)
+		check = checkRoleChange(&user, params.Role, actor)
+	}
+
+	if check != nil {
+		result.Result = BulkResultFailed
+		result.Error = check.Error()
+		return result
+	}
+
+	if result.Before == result.After {
+		result.Result = BulkResultSkipped
+		return result
+	}
+
+	// Durum değişiklikleri dry-run sonucunun doğru olması için burada da kontrol edilir
+	if event, reason, ok := bulkStatusEvent(job.Action, params); ok {
+		if err := checkStatusTransition(&user, event, actor, reason); err != nil {
+			result.Result = BulkResultFailed
+			result.Error = err.Error()
+			return result
+		}
+	}
+
+	if job.DryRun {
+		result.Result = BulkResultWouldApply
+		return result
+	}
+
+	var err error
+	switch job.Action {
+	case models.BulkActionBan:
+		err = s.authService.BanUser(ctx, userID, params.BanReason, params.BanDuration, actor)
+	case models.BulkActionUnban:
+		err = s.authService.UnbanUser(ctx, userID, actor)
+	case models.BulkActionStatus:
+		err = s.authService.UpdateUserStatus(ctx, userID, params.Status, actor)
+	case models.BulkActionRole:
+		err = s.authService.UpdateUserRole(ctx, userID, params.Role, actor)
+	}
+
+	if err != nil {
+		result.Result = BulkResultFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Result = BulkResultApplied
+	return result
+}
+
+// bulkStatusEvent returns the status machine event a bulk action applies, if any
+func bulkStatusEvent(action models.BulkAction, params models.BulkActionParams) (models.StatusEvent, string, bool) {
+	switch action {
+	case models.BulkActionBan:
+		return models.StatusEventBan, params.BanReason, true
+	case models.BulkActionUnban:
+		return models.StatusEventUnban, "", true
+	case models.BulkActionStatus:
+		// Eşleşmeyen durumlar boş olay ile reddedilir
+		event, _ := statusEventFor(params.Status)
+		return event, "", true
+	}
+	return "", "", false
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
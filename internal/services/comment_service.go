@@ -0,0 +1,374 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrCommentNotFound    = errors.New("comment not found")
+	ErrInvalidComment     = errors.New("comment must be 15 to 600 characters")
+	ErrCommentEditExpired = errors.New("comment can no longer be edited")
+	ErrSelfCommentUpvote  = errors.New("users cannot upvote their own comments")
+)
+
+const (
+	MinCommentLength = 15
+	MaxCommentLength = 600
+
+	// defaultCommentEditWindow is used when COMMENT_EDIT_WINDOW is not set
+	defaultCommentEditWindow = 5 * time.Minute
+
+	// defaultInlineComments is the number of comments shown with a post
+	defaultInlineComments = 5
+)
+
+// PostComments are the top comments of a post and the number of all its comments
+type PostComments struct {
+	Comments []models.Comment
+	Total    int64
+}
+
+type CommentService struct {
+	db *gorm.DB
+}
+
+func NewCommentService(db *gorm.DB) *CommentService {
+	return &CommentService{db: db}
+}
+
+// Create adds a comment to a post. Commenting on posts of other users needs the
+// comment_everywhere privilege, except on answers to the actor's own question.
+func (s *CommentService) Create(ctx context.Context, postType models.PostType, postID uint, body string, actor *Actor) (*models.Comment, error) {
+	db := s.db.WithContext(ctx)
+	author, err := activeAuthor(db, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err = normalizeCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	owners, err := commentPostOwners(db, postType, postID)
+	if err != nil {
+		return nil, err
+	}
+	if !containsID(owners, actor.ID) {
+		if err := checkPrivilege(db, actor, models.PrivilegeCommentEverywhere); err != nil {
+			return nil, err
+		}
+	}
+
+	comment := &models.Comment{
+		PostType: postType,
+		PostID:   postID,
+		AuthorID: author.ID,
+		Author:   *author,
+		Body:     body,
+	}
+	if err := db.Omit("Author").Create(comment).Error; err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// Get returns a comment with its author
+func (s *CommentService) Get(id uint) (*models.Comment, error) {
+	var comment models.Comment
+	if err := s.db.Preload("Author", withDeletedUsers).First(&comment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// List returns a page of the comments of a post in the order they were written
+func (s *CommentService) List(postType models.PostType, postID uint, page, limit int) ([]models.Comment, int64, error) {
+	if _, err := commentPostOwners(s.db, postType, postID); err != nil {
+		return nil, 0, err
+	}
+
+	query := s.db.Model(&models.Comment{}).Where("post_type = ? AND post_id = ?", postType, postID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var comments []models.Comment
+	if err := query.Preload("Author", withDeletedUsers).
+		Order("created_at, id").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&comments).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
+
+// TopComments returns the highest scored comments of every post, oldest first within
+// a post, together with the comment count of the post
+func (s *CommentService) TopComments(postType models.PostType, postIDs []uint) (map[uint]*PostComments, error) {
+	result := make(map[uint]*PostComments, len(postIDs))
+	if len(postIDs) == 0 {
+		return result, nil
+	}
+	for _, id := range postIDs {
+		result[id] = &PostComments{Comments: []models.Comment{}}
+	}
+
+	var counts []struct {
+		PostID uint
+		Total  int64
+	}
+	if err := s.db.Model(&models.Comment{}).
+		Select("post_id, COUNT(*) AS total").
+		Where("post_type = ? AND post_id IN ?", postType, postIDs).
+		Group("post_id").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	for _, count := range counts {
+		result[count.PostID].Total = count.Total
+	}
+
+	ranked := s.db.Model(&models.Comment{}).
+		Select("comments.id, ROW_NUMBER() OVER (PARTITION BY comments.post_id ORDER BY comments.score DESC, comments.created_at, comments.id) AS comment_rank").
+		Where("comments.post_type = ? AND comments.post_id IN ?", postType, postIDs)
+
+	var comments []models.Comment
+	if err := s.db.Preload("Author", withDeletedUsers).
+		Where("id IN (?)", s.db.Table("(?) AS ranked", ranked).Select("id").Where("comment_rank <= ?", defaultInlineComments)).
+		Order("created_at, id").
+		Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	for _, comment := range comments {
+		result[comment.PostID].Comments = append(result[comment.PostID].Comments, comment)
+	}
+
+	return result, nil
+}
+
+// Update edits a comment. Only the author can edit it, and only within COMMENT_EDIT_WINDOW.
+func (s *CommentService) Update(ctx context.Context, id uint, body string, actor *Actor) (*models.Comment, error) {
+	comment, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID != actor.ID {
+		return nil, ErrForbidden
+	}
+	if _, err := activeAuthor(s.db.WithContext(ctx), actor.ID); err != nil {
+		return nil, err
+	}
+
+	window := utils.GetEnvDuration("COMMENT_EDIT_WINDOW", defaultCommentEditWindow)
+	if time.Since(comment.CreatedAt) > window {
+		return nil, ErrCommentEditExpired
+	}
+
+	body, err = normalizeCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WithContext(ctx).Model(comment).Update("body", body).Error; err != nil {
+		return nil, err
+	}
+
+	return s.Get(id)
+}
+
+// Delete soft deletes a comment. The author, editors and admins can delete it;
+// deletions by moderators are written to the audit log.
+func (s *CommentService) Delete(ctx context.Context, id uint, actor *Actor) error {
+	comment, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID != actor.ID && !isEditorRole(actor.Role) {
+		return ErrForbidden
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(comment).Error; err != nil {
+			return err
+		}
+
+		if comment.AuthorID == actor.ID {
+			return nil
+		}
+		return recordAuditEvent(tx, actor, auditEntry{
+			Action:       models.AuditActionCommentDeleted,
+			TargetUserID: comment.AuthorID,
+			Details: map[string]interface{}{
+				"comment_id": comment.ID,
+				"post_type":  comment.PostType,
+				"post_id":    comment.PostID,
+			},
+		})
+	})
+}
+
+// Upvote adds the upvote of actor to a comment. Upvoting twice leaves the score unchanged.
+func (s *CommentService) Upvote(ctx context.Context, id uint, actor *Actor) (*VoteResult, error) {
+	if _, err := activeAuthor(s.db.WithContext(ctx), actor.ID); err != nil {
+		return nil, err
+	}
+
+	result := &VoteResult{UserVote: models.VoteUp}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		comment, err := lockComment(tx, id)
+		if err != nil {
+			return err
+		}
+		if comment.AuthorID == actor.ID {
+			return ErrSelfCommentUpvote
+		}
+
+		insert := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.CommentVote{UserID: actor.ID, CommentID: comment.ID})
+		if insert.Error != nil {
+			return insert.Error
+		}
+
+		result.Score = comment.Score + int(insert.RowsAffected)
+		return addCommentScore(tx, comment.ID, int(insert.RowsAffected))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RetractUpvote removes the upvote of actor from a comment
+func (s *CommentService) RetractUpvote(ctx context.Context, id uint, actor *Actor) (*VoteResult, error) {
+	result := &VoteResult{}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		comment, err := lockComment(tx, id)
+		if err != nil {
+			return err
+		}
+
+		remove := tx.Where("user_id = ? AND comment_id = ?", actor.ID, comment.ID).Delete(&models.CommentVote{})
+		if remove.Error != nil {
+			return remove.Error
+		}
+
+		result.Score = comment.Score - int(remove.RowsAffected)
+		return addCommentScore(tx, comment.ID, -int(remove.RowsAffected))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UserUpvotes returns the IDs of the given comments the user has upvoted
+func (s *CommentService) UserUpvotes(userID uint, commentIDs []uint) (map[uint]bool, error) {
+	upvoted := make(map[uint]bool)
+	if userID == 0 || len(commentIDs) == 0 {
+		return upvoted, nil
+	}
+
+	var ids []uint
+	if err := s.db.Model(&models.CommentVote{}).
+		Where("user_id = ? AND comment_id IN ?", userID, commentIDs).
+		Pluck("comment_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		upvoted[id] = true
+	}
+	return upvoted, nil
+}
+
+// commentPostOwners returns the users who may always comment on a post: the author
+// of the post and, for an answer, the author of its question
+func commentPostOwners(tx *gorm.DB, postType models.PostType, postID uint) ([]uint, error) {
+	switch postType {
+	case models.PostTypeQuestion:
+		var question models.Question
+		if err := tx.Select("id", "author_id").First(&question, postID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrQuestionNotFound
+			}
+			return nil, err
+		}
+		return []uint{question.AuthorID}, nil
+	case models.PostTypeAnswer:
+		var answer models.Answer
+		if err := tx.Select("id", "question_id", "author_id").First(&answer, postID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrAnswerNotFound
+			}
+			return nil, err
+		}
+		var question models.Question
+		if err := tx.Select("id", "author_id").First(&question, answer.QuestionID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrAnswerNotFound
+			}
+			return nil, err
+		}
+		return []uint{answer.AuthorID, question.AuthorID}, nil
+	}
+	return nil, errUnknownPostType
+}
+
+// normalizeCommentBody puts a comment on a single line and checks its length.
+// Comments are markdown-lite, so line breaks and block formatting are not kept.
+func normalizeCommentBody(body string) (string, error) {
+	body = strings.Join(strings.Fields(body), " ")
+	if n := utf8.RuneCountInString(body); n < MinCommentLength || n > MaxCommentLength {
+		return "", ErrInvalidComment
+	}
+	return body, nil
+}
+
+func lockComment(tx *gorm.DB, id uint) (*models.Comment, error) {
+	var comment models.Comment
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "author_id", "score").First(&comment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// addCommentScore changes the cached score of a locked comment without touching updated_at
+func addCommentScore(tx *gorm.DB, commentID uint, delta int) error {
+	if delta == 0 {
+		return nil
+	}
+	return tx.Model(&models.Comment{}).Where("id = ?", commentID).
+		UpdateColumn("score", gorm.Expr("score + ?", delta)).Error
+}
+
+func containsID(ids []uint, id uint) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// activeAuthor loads the user posting content. The status in the JWT can be stale,
+// so banned or passive users are rejected here as well.
+func activeAuthor(tx *gorm.DB, userID uint) (*models.User, error) {
+	var user models.User
+	if err := tx.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	if user.Status != models.StatusActive {
+		return nil, ErrUserNotActive
+	}
+	return &user, nil
+}
+
+// canManageContent reports whether actor may change content written by authorID.
+// Admins moderate content of every user.
+func canManageContent(authorID uint, actor *Actor) bool {
+	return actor.ID == authorID || isAdminRole(actor.Role)
+}
+
+// checkEditPermission allows the author, editors and admins to edit a post. Other
+// users need the edit_posts privilege.
+func checkEditPermission(tx *gorm.DB, authorID uint, actor *Actor) error {
+	if canManageContent(authorID, actor) || isEditorRole(actor.Role) {
+		return nil
+	}
+	return checkPrivilege(tx, actor, models.PrivilegeEditPosts)
+}
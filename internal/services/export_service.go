@@ -0,0 +1,383 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/pkg/mailer"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrExportInProgress = errors.New("a data export is already in progress")
+	ErrExportNotFound   = errors.New("data export not found")
+	ErrExportExpired    = errors.New("data export has expired")
+)
+
+const (
+	// defaultExportTTL is used when DATA_EXPORT_TTL is not set
+	defaultExportTTL = 48 * time.Hour
+
+	// Bu süreden uzun bekleyen dışa aktarmalar başarısız sayılır (ör. sunucu yeniden başladı)
+	staleExportTimeout = time.Hour
+)
+
+// exportSection produces one JSON file of the export archive
+type exportSection struct {
+	File  string
+	Build func(tx *gorm.DB, user *models.User) (interface{}, error)
+}
+
+var exportSections = []exportSection{
+	{File: "account.json", Build: exportAccount},
+	{File: "last_login.json", Build: exportLastLogin},
+	{File: "sanctions.json", Build: exportSanctions},
+	{File: "questions.json", Build: exportQuestions},
+	{File: "answers.json", Build: exportAnswers},
+	{File: "comments.json", Build: exportComments},
+	{File: "edits.json", Build: exportEdits},
+	{File: "suggested_edits.json", Build: exportSuggestedEdits},
+	{File: "votes.json", Build: exportVotes},
+	{File: "reputation.json", Build: exportReputation},
+	{File: "tag_preferences.json", Build: exportTagPreferences},
+}
+
+type ExportService struct {
+	db *gorm.DB
+}
+
+func NewExportService(db *gorm.DB) *ExportService {
+	return &ExportService{db: db}
+}
+
+// RequestExport creates a new export request and builds the archive in the background
+func (s *ExportService) RequestExport(ctx context.Context, userID uint) (*models.DataExport, error) {
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&models.DataExport{}).
+		Where("user_id = ? AND status IN ?", userID, []models.DataExportStatus{models.ExportStatusPending, models.ExportStatusProcessing}).
+		Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, ErrExportInProgress
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &models.DataExport{
+		UserID: userID,
+		Status: models.ExportStatusPending,
+		Token:  token,
+	}
+	if err := s.db.WithContext(ctx).Create(export).Error; err != nil {
+		return nil, err
+	}
+
+	go s.build(export.ID)
+
+	return export, nil
+}
+
+// GetLatestExport returns the most recent export request of the user
+func (s *ExportService) GetLatestExport(userID uint) (*models.DataExport, error) {
+	var export models.DataExport
+	if err := s.db.Where("user_id = ?", userID).Order("created_at DESC").First(&export).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExportNotFound
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+// GetDownload returns a ready export by its download token
+func (s *ExportService) GetDownload(token string) (*models.DataExport, error) {
+	var export models.DataExport
+	if err := s.db.Where("token = ?", token).First(&export).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExportNotFound
+		}
+		return nil, err
+	}
+
+	if export.Status == models.ExportStatusExpired || (export.ExpiresAt != nil && time.Now().After(*export.ExpiresAt)) {
+		return nil, ErrExportExpired
+	}
+	if export.Status != models.ExportStatusReady {
+		return nil, ErrExportNotFound
+	}
+
+	return &export, nil
+}
+
+// CleanupExports removes expired archives and fails exports that never finished
+func (s *ExportService) CleanupExports(ctx context.Context) error {
+	var expired []models.DataExport
+	if err := s.db.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", models.ExportStatusReady, time.Now()).
+		Find(&expired).Error; err != nil {
+		return err
+	}
+
+	for i := range expired {
+		if err := os.Remove(expired[i].FilePath); err != nil && !os.IsNotExist(err) {
+			log.Printf("Failed to remove data export %d: %v", expired[i].ID, err)
+			continue
+		}
+		if err := s.db.WithContext(ctx).Model(&expired[i]).Updates(map[string]interface{}{
+			"status":    models.ExportStatusExpired,
+			"file_path": "",
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	return s.db.WithContext(ctx).Model(&models.DataExport{}).
+		Where("status IN ? AND created_at < ?",
+			[]models.DataExportStatus{models.ExportStatusPending, models.ExportStatusProcessing},
+			time.Now().Add(-staleExportTimeout)).
+		Updates(map[string]interface{}{
+			"status": models.ExportStatusFailed,
+			"error":  "export timed out",
+		}).Error
+}
+
+func (s *ExportService) build(exportID uint) {
+	var export models.DataExport
+	if err := s.db.First(&export, exportID).Error; err != nil {
+		log.Printf("Failed to load data export %d: %v", exportID, err)
+		return
+	}
+
+	if err := s.db.Model(&export).Update("status", models.ExportStatusProcessing).Error; err != nil {
+		log.Printf("Failed to update data export %d: %v", exportID, err)
+		return
+	}
+
+	var user models.User
+	path, err := s.writeArchive(&export, &user)
+	if err != nil {
+		log.Printf("Data export %d failed: %v", exportID, err)
+		s.db.Model(&export).Updates(map[string]interface{}{
+			"status": models.ExportStatusFailed,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(utils.GetEnvDuration("DATA_EXPORT_TTL", defaultExportTTL))
+	if err := s.db.Model(&export).Updates(map[string]interface{}{
+		"status":       models.ExportStatusReady,
+		"file_path":    path,
+		"completed_at": now,
+		"expires_at":   expiresAt,
+	}).Error; err != nil {
+		log.Printf("Failed to update data export %d: %v", exportID, err)
+		return
+	}
+
+	link := fmt.Sprintf("%s/api/v1/exports/%s", os.Getenv("APP_URL"), export.Token)
+	body := fmt.Sprintf("Hello %s,\n\nYour personal data export is ready. You can download it until %s:\n\n%s\n",
+		user.Username, expiresAt.Format(time.RFC1123), link)
+	if err := mailer.Send(user.Email, "Your data export is ready", body); err != nil {
+		log.Printf("Failed to notify user %d about data export: %v", user.ID, err)
+	}
+}
+
+// writeArchive builds the zip archive of the export and returns its path
+func (s *ExportService) writeArchive(export *models.DataExport, user *models.User) (string, error) {
+	if err := s.db.First(user, export.UserID).Error; err != nil {
+		return "", err
+	}
+
+	uploadDir := os.Getenv("UPLOAD_DIR")
+	if uploadDir == "" {
+		uploadDir = "uploads"
+	}
+	dir := filepath.Join(uploadDir, "exports")
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, export.Token+".zip")
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+	for _, section := range exportSections {
+		data, err := section.Build(s.db, user)
+		if err != nil {
+			os.Remove(path)
+			return "", fmt.Errorf("%s: %w", section.File, err)
+		}
+
+		w, err := archive.Create(section.File)
+		if err != nil {
+			os.Remove(path)
+			return "", err
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(data); err != nil {
+			os.Remove(path)
+			return "", err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+
+	return path, nil
+}
+
+func exportAccount(tx *gorm.DB, user *models.User) (interface{}, error) {
+	return map[string]interface{}{
+		"id":              user.ID,
+		"username":        user.Username,
+		"email":           user.Email,
+		"avatar":          user.Avatar,
+		"status":          user.Status,
+		"role":            user.Role,
+		"created_at":      user.CreatedAt,
+		"last_login_date": user.LastLoginDate,
+	}, nil
+}
+
+// exportLastLogin returns the date of the last login. Individual logins and sessions
+// are not stored, so there is no login history to export.
+func exportLastLogin(tx *gorm.DB, user *models.User) (interface{}, error) {
+	return map[string]interface{}{
+		"last_login_date": user.LastLoginDate,
+	}, nil
+}
+
+func exportSanctions(tx *gorm.DB, user *models.User) (interface{}, error) {
+	var events []models.AuditEvent
+	if err := tx.Where("target_user_id = ?", user.ID).Order("created_at").Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	history := make([]map[string]interface{}, 0, len(events))
+	for _, event := range events {
+		history = append(history, map[string]interface{}{
+			"action":     event.Action,
+			"details":    rawJSONOrNil(event.Details),
+			"before":     rawJSONOrNil(event.BeforeState),
+			"after":      rawJSONOrNil(event.AfterState),
+			"created_at": event.CreatedAt,
+		})
+	}
+
+	var warnings []models.UserWarning
+	if err := tx.Where("user_id = ?", user.ID).Order("created_at").Find(&warnings).Error; err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"ban_reason":   user.BanReason,
+		"ban_end_date": user.BanEndDate,
+		"warnings":     warnings,
+		"history":      history,
+	}, nil
+}
+
+func rawJSONOrNil(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return json.RawMessage(value)
+}
+
+func exportQuestions(tx *gorm.DB, user *models.User) (interface{}, error) {
+	var questions []models.Question
+	if err := tx.Where("author_id = ?", user.ID).Order("created_at").Find(&questions).Error; err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func exportAnswers(tx *gorm.DB, user *models.User) (interface{}, error) {
+	var answers []models.Answer
+	if err := tx.Where("author_id = ?", user.ID).Order("created_at").Find(&answers).Error; err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+func exportComments(tx *gorm.DB, user *models.User) (interface{}, error) {
+	var comments []models.Comment
+	if err := tx.Where("author_id = ?", user.ID).Order("created_at").Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	var upvotes []models.CommentVote
+	if err := tx.Where("user_id = ?", user.ID).Order("created_at").Find(&upvotes).Error; err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"comments": comments,
+		"upvotes":  upvotes,
+	}, nil
+}
+
+func exportEdits(tx *gorm.DB, user *models.User) (interface{}, error) {
+	var revisions []models.PostRevision
+	if err := tx.Where("editor_id = ?", user.ID).Order("created_at, id").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func exportSuggestedEdits(tx *gorm.DB, user *models.User) (interface{}, error) {
+	var edits []models.SuggestedEdit
+	if err := tx.Where("author_id = ?", user.ID).Order("created_at, id").Find(&edits).Error; err != nil {
+		return nil, err
+	}
+	return edits, nil
+}
+
+func exportVotes(tx *gorm.DB, user *models.User) (interface{}, error) {
+	var votes []models.Vote
+	if err := tx.Where("user_id = ?", user.ID).Order("created_at").Find(&votes).Error; err != nil {
+		return nil, err
+	}
+	return votes, nil
+}
+
+func exportReputation(tx *gorm.DB, user *models.User) (interface{}, error) {
+	var events []models.ReputationEvent
+	if err := tx.Where("user_id = ?", user.ID).Order("created_at, id").Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"reputation": user.Reputation,
+		"events":     events,
+	}, nil
+}
+
+func exportTagPreferences(tx *gorm.DB, user *models.User) (interface{}, error) {
+	var prefs []models.UserTagPreference
+	if err := tx.Preload("Tag", omitTagWiki).
+		Where("user_id = ?", user.ID).Order("created_at").Find(&prefs).Error; err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
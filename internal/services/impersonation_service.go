@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrImpersonationNotAllowed = errors.New("only non-admin users can be impersonated")
+	ErrImpersonationNotFound   = errors.New("impersonation session not found")
+)
+
+// defaultImpersonationTTL is used when IMPERSONATION_TTL is not set
+const defaultImpersonationTTL = time.Hour
+
+type ImpersonationService struct {
+	db *gorm.DB
+}
+
+func NewImpersonationService(db *gorm.DB) *ImpersonationService {
+	return &ImpersonationService{db: db}
+}
+
+// Start opens an impersonation session for a SUPER_ADMIN and returns a token for the user
+func (s *ImpersonationService) Start(ctx context.Context, userID uint, reason string, actor *Actor) (*models.ImpersonationSession, string, error) {
+	if actor.Role != models.RoleSuperAdmin || actor.ImpersonatorID != 0 {
+		return nil, "", ErrForbidden
+	}
+
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", ErrUserNotFound
+		}
+		return nil, "", err
+	}
+
+	// Yöneticilerin yetkileri başka bir yönetici tarafından kullanılamaz
+	if user.ID == actor.ID || (user.Role != models.RoleUser && user.Role != models.RoleEditor) {
+		return nil, "", ErrImpersonationNotAllowed
+	}
+
+	session := &models.ImpersonationSession{
+		ImpersonatorID: actor.ID,
+		UserID:         user.ID,
+		Reason:         reason,
+		ExpiresAt:      time.Now().Add(utils.GetEnvDuration("IMPERSONATION_TTL", defaultImpersonationTTL)),
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+
+		return recordAuditEvent(tx, actor, auditEntry{
+			Action:       models.AuditActionImpersonationStarted,
+			TargetUserID: user.ID,
+			Details: map[string]interface{}{
+				"session_id": session.ID,
+				"reason":     reason,
+				"expires_at": formatAuditTime(&session.ExpiresAt),
+			},
+		})
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, err := utils.GenerateImpersonationJWT(&user, actor.ID, session.ID, session.ExpiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return session, token, nil
+}
+
+// Stop ends an impersonation session. It is called with the impersonation token itself,
+// so the actor is the impersonated user and ImpersonatorID the admin.
+func (s *ImpersonationService) Stop(ctx context.Context, sessionID uint, actor *Actor) error {
+	var session models.ImpersonationSession
+	if err := s.db.First(&session, sessionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrImpersonationNotFound
+		}
+		return err
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.ImpersonationSession{}).
+			Where("id = ? AND ended_at IS NULL", session.ID).
+			Update("ended_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrImpersonationNotFound
+		}
+
+		// Oturumu kapatan asıl kişi yönetici olduğu için olay onun adına yazılır
+		return recordAuditEvent(tx, &Actor{
+			ID:        session.ImpersonatorID,
+			IP:        actor.IP,
+			RequestID: actor.RequestID,
+		}, auditEntry{
+			Action:       models.AuditActionImpersonationStopped,
+			TargetUserID: session.UserID,
+			Details: map[string]interface{}{
+				"session_id": session.ID,
+			},
+		})
+	})
+}
+
+// IsActive reports whether a session has neither been stopped nor expired.
+// AuthMiddleware calls it for every request made with an impersonation token.
+func (s *ImpersonationService) IsActive(sessionID uint) bool {
+	var count int64
+	if err := s.db.Model(&models.ImpersonationSession{}).
+		Where("id = ? AND ended_at IS NULL AND expires_at > ?", sessionID, time.Now()).
+		Count(&count).Error; err != nil {
+		return false
+	}
+	return count > 0
+}
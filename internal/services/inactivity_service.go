@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/pkg/mailer"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var ErrReactivationLinkInvalid = errors.New("reactivation link is invalid, expired or already used")
+
+// errNoLongerInactive rolls back a passivation when the user signed in meanwhile
+var errNoLongerInactive = errors.New("user signed in after being selected for passivation")
+
+const (
+	// defaultInactivityPeriod is used when INACTIVITY_PASSIVATION_PERIOD is not set
+	defaultInactivityPeriod = 365 * 24 * time.Hour
+	// defaultInactivityWarningLead is used when INACTIVITY_WARNING_LEAD is not set
+	defaultInactivityWarningLead = 14 * 24 * time.Hour
+	// defaultReactivationLinkTTL is used when REACTIVATION_LINK_TTL is not set
+	defaultReactivationLinkTTL = 30 * 24 * time.Hour
+
+	inactivityBatchSize = 100
+)
+
+type InactivityService struct {
+	db *gorm.DB
+}
+
+func NewInactivityService(db *gorm.DB) *InactivityService {
+	return &InactivityService{db: db}
+}
+
+// PassivateInactiveAccounts warns active accounts that are about to reach the inactivity
+// period and makes them passive once it is over. An account is only made passive after
+// the warning has been sent at least INACTIVITY_WARNING_LEAD earlier, so a missed run
+// never passivates an account without notice. SUPER_ADMIN accounts are never touched.
+func (s *InactivityService) PassivateInactiveAccounts(ctx context.Context) error {
+	period := utils.GetEnvDuration("INACTIVITY_PASSIVATION_PERIOD", defaultInactivityPeriod)
+	if period <= 0 {
+		return nil
+	}
+	lead := utils.GetEnvDuration("INACTIVITY_WARNING_LEAD", defaultInactivityWarningLead)
+	if lead > period {
+		lead = period
+	}
+
+	now := time.Now()
+	if err := s.warnInactiveAccounts(ctx, now.Add(-(period - lead)), period); err != nil {
+		return err
+	}
+	return s.passivateWarnedAccounts(ctx, now.Add(-period), now.Add(-lead))
+}
+
+// warnInactiveAccounts emails every active account whose last login is before cutoff
+// and that has not been warned since that login
+func (s *InactivityService) warnInactiveAccounts(ctx context.Context, cutoff time.Time, period time.Duration) error {
+	var lastID uint
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var users []models.User
+		if err := s.db.WithContext(ctx).
+			Where("id > ? AND status = ? AND role <> ? AND last_login_date < ?", lastID, models.StatusActive, models.RoleSuperAdmin, cutoff).
+			Where("inactivity_warned_at IS NULL OR inactivity_warned_at < last_login_date").
+			Order("id").
+			Limit(inactivityBatchSize).
+			Find(&users).Error; err != nil {
+			return err
+		}
+
+		for i := range users {
+			user := &users[i]
+			deadline := user.LastLoginDate.Add(period)
+			body := fmt.Sprintf("Hello %s,\n\nYou have not signed in for a long time. Your account will be made passive on %s unless you sign in before then.\n\nYou can reactivate a passive account at any time by signing in.",
+				user.Username, deadline.Format("2006-01-02"))
+
+			// Uyarı gönderilemezse işaretlenmez, bir sonraki çalıştırmada tekrar denenir
+			if err := mailer.Send(user.Email, "Your account will be made passive", body); err != nil {
+				log.Printf("Failed to send inactivity warning to user %d: %v", user.ID, err)
+				continue
+			}
+
+			if err := s.db.WithContext(ctx).Model(user).UpdateColumn("inactivity_warned_at", time.Now()).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(users) < inactivityBatchSize {
+			return nil
+		}
+		lastID = users[len(users)-1].ID
+	}
+}
+
+// passivateWarnedAccounts makes accounts passive whose last login is before cutoff and
+// that were warned after that login but no later than warnedBefore
+func (s *InactivityService) passivateWarnedAccounts(ctx context.Context, cutoff, warnedBefore time.Time) error {
+	var lastID uint
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var users []models.User
+		if err := s.db.WithContext(ctx).
+			Where("id > ? AND status = ? AND role <> ? AND last_login_date < ?", lastID, models.StatusActive, models.RoleSuperAdmin, cutoff).
+			Where("inactivity_warned_at >= last_login_date AND inactivity_warned_at <= ?", warnedBefore).
+			Order("id").
+			Limit(inactivityBatchSize).
+			Find(&users).Error; err != nil {
+			return err
+		}
+
+		for i := range users {
+			if err := s.passivate(ctx, &users[i], cutoff); err != nil {
+				log.Printf("Failed to passivate inactive user %d: %v", users[i].ID, err)
+			}
+		}
+
+		if len(users) < inactivityBatchSize {
+			return nil
+		}
+		lastID = users[len(users)-1].ID
+	}
+}
+
+func (s *InactivityService) passivate(ctx context.Context, user *models.User, cutoff time.Time) error {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	before := userSnapshot(user)
+	expiresAt := time.Now().Add(utils.GetEnvDuration("REACTIVATION_LINK_TTL", defaultReactivationLinkTTL))
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := transitionStatus(tx, user, models.StatusEventPassivate, nil, "inactivity"); err != nil {
+			return err
+		}
+		// Seçildikten sonra giriş yapan kullanıcı pasifleştirilmez
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND status = ? AND last_login_date < ?", user.ID, models.StatusActive, cutoff).
+			Update("status", user.Status)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errNoLongerInactive
+		}
+
+		if err := tx.Create(&models.AccountReactivationToken{
+			UserID:    user.ID,
+			TokenHash: hashVerificationCode(token),
+			ExpiresAt: expiresAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		// Sistem tarafından yapılan işlem, aktör yok
+		return recordUserChange(tx, nil, models.AuditActionUserPassivated, before, user, map[string]interface{}{
+			"reason":        "inactivity",
+			"last_login_at": formatAuditTime(&user.LastLoginDate),
+		})
+	})
+	if errors.Is(err, errNoLongerInactive) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/account/reactivate?token=%s", os.Getenv("APP_URL"), token)
+	body := fmt.Sprintf("Hello %s,\n\nYour account has been made passive because you have not signed in for a long time.\n\nSign in again or use the link below to reactivate it:\n%s\n\nThe link is valid until %s.",
+		user.Username, link, expiresAt.Format("2006-01-02"))
+	if err := mailer.Send(user.Email, "Your account has been made passive", body); err != nil {
+		// Hesap giriş yapılarak da etkinleştirilebildiği için e-posta hatası işlemi geri almaz
+		log.Printf("Failed to send reactivation link to user %d: %v", user.ID, err)
+	}
+
+	log.Printf("User %d made passive after inactivity", user.ID)
+	return nil
+}
+
+// ReactivateWithLink reactivates a passive account with the single-use link emailed when it
+// was made passive. The link stops working once the account has been reactivated in any way.
+func (s *InactivityService) ReactivateWithLink(ctx context.Context, token, ip, requestID string) (*models.User, error) {
+	if token == "" {
+		return nil, ErrReactivationLinkInvalid
+	}
+
+	var user models.User
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		var link models.AccountReactivationToken
+		if err := tx.Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", hashVerificationCode(token), now).
+			First(&link).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrReactivationLinkInvalid
+			}
+			return err
+		}
+
+		result := tx.Model(&models.AccountReactivationToken{}).
+			Where("id = ? AND used_at IS NULL", link.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrReactivationLinkInvalid
+		}
+
+		if err := tx.First(&user, link.UserID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrReactivationLinkInvalid
+			}
+			return err
+		}
+
+		// Bağlantı yalnızca sistem tarafından pasifleştirilen hesapları etkinleştirir
+		if user.Status != models.StatusPassive {
+			return ErrReactivationLinkInvalid
+		}
+		trigger, err := lastPassivationTrigger(tx, user.ID)
+		if err != nil {
+			return err
+		}
+		if trigger != models.StatusTriggerSystem {
+			return ErrReactivationLinkInvalid
+		}
+
+		return activatePassiveUser(tx, &user, &Actor{ID: user.ID, Role: user.Role, IP: ip, RequestID: requestID}, "reactivation_link")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// lastPassivationTrigger returns who made the user passive the last time
+func lastPassivationTrigger(tx *gorm.DB, userID uint) (string, error) {
+	var history models.UserStatusHistory
+	if err := tx.Where("user_id = ? AND to_status = ?", userID, models.StatusPassive).
+		Order("created_at DESC, id DESC").
+		First(&history).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return history.TriggeredBy, nil
+}
+
+// activatePassiveUser makes a passive user active again on the user's own behalf
+// and invalidates outstanding reactivation links
+func activatePassiveUser(tx *gorm.DB, user *models.User, actor *Actor, source string) error {
+	before := userSnapshot(user)
+
+	if err := transitionStatus(tx, user, models.StatusEventActivate, actor, ""); err != nil {
+		return err
+	}
+	if err := tx.Model(user).Update("status", user.Status).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&models.AccountReactivationToken{}).
+		Where("user_id = ? AND used_at IS NULL", user.ID).
+		Update("used_at", time.Now()).Error; err != nil {
+		return err
+	}
+
+	return recordUserChange(tx, actor, models.AuditActionStatusChanged, before, user, map[string]interface{}{
+		"source": source,
+	})
+}
@@ -0,0 +1,381 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/pkg/mailer"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvitationNotFound      = errors.New("invitation not found")
+	ErrInvitationInvalid       = errors.New("invitation link is invalid or has already been used")
+	ErrInvitationExpired       = errors.New("invitation has expired")
+	ErrInvitationPending       = errors.New("a pending invitation for this email already exists")
+	ErrInvitationNotApplicable = errors.New("account already has this role or a higher one")
+	ErrInvitationSignup        = errors.New("username and password are required to create an account")
+)
+
+// defaultInvitationTTL is used when INVITATION_TTL is not set
+const defaultInvitationTTL = 7 * 24 * time.Hour
+
+// roleRanks orders roles so that accepting an invitation never lowers a role
+var roleRanks = map[models.UserRole]int{
+	models.RoleUser:       0,
+	models.RoleEditor:     1,
+	models.RoleAdmin:      2,
+	models.RoleSuperAdmin: 3,
+}
+
+type InvitationService struct {
+	db *gorm.DB
+}
+
+func NewInvitationService(db *gorm.DB) *InvitationService {
+	return &InvitationService{db: db}
+}
+
+// Create stores an invitation and emails the signed link to the invited address
+func (s *InvitationService) Create(ctx context.Context, email string, role models.UserRole, actor *Actor) (*models.AdminInvitation, error) {
+	if err := checkInvitation(role, actor); err != nil {
+		return nil, err
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	nonce, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &models.AdminInvitation{
+		Email:     email,
+		Role:      role,
+		NonceHash: hashVerificationCode(nonce),
+		Status:    models.InvitationPending,
+		InvitedBy: actor.ID,
+		ExpiresAt: time.Now().Add(utils.GetEnvDuration("INVITATION_TTL", defaultInvitationTTL)),
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var pending int64
+		if err := tx.Model(&models.AdminInvitation{}).
+			Where("email = ? AND status = ? AND expires_at > ?", email, models.InvitationPending, time.Now()).
+			Count(&pending).Error; err != nil {
+			return err
+		}
+		if pending > 0 {
+			return ErrInvitationPending
+		}
+
+		if err := tx.Create(invitation).Error; err != nil {
+			return err
+		}
+
+		// E-posta adresi denetim kaydına yazılmaz, davetiye ID'si yeterli
+		return recordAuditEvent(tx, actor, auditEntry{
+			Action: models.AuditActionInvitationCreated,
+			Details: map[string]interface{}{
+				"invitation_id": invitation.ID,
+				"role":          role,
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	token := signInvitation(invitation, nonce)
+	link := fmt.Sprintf("%s/invitations/accept?token=%s", os.Getenv("APP_URL"), token)
+	body := fmt.Sprintf("Hello,\n\nYou have been invited to join as %s. Open the link below to accept the invitation until %s:\n\n%s\n\nThe link can only be used once.\n",
+		role, invitation.ExpiresAt.Format(time.RFC1123), link)
+	if err := mailer.Send(email, "You have been invited", body); err != nil {
+		log.Printf("Failed to send invitation %d: %v", invitation.ID, err)
+	}
+
+	return invitation, nil
+}
+
+// ListInvitations davetiyeleri listeler. Süresi dolmuş bekleyen davetiyeler expired olarak döner.
+func (s *InvitationService) ListInvitations(status string, page, limit int) ([]models.AdminInvitation, int64, error) {
+	query := s.db.Model(&models.AdminInvitation{})
+	switch models.InvitationStatus(status) {
+	case "":
+	case models.InvitationPending:
+		query = query.Where("status = ? AND expires_at > ?", models.InvitationPending, time.Now())
+	case models.InvitationExpired:
+		query = query.Where("status = ? AND expires_at <= ?", models.InvitationPending, time.Now())
+	default:
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var invitations []models.AdminInvitation
+	if err := query.Order("id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&invitations).Error; err != nil {
+		return nil, 0, err
+	}
+
+	for i := range invitations {
+		markExpired(&invitations[i])
+	}
+
+	return invitations, total, nil
+}
+
+// Revoke cancels a pending invitation so that its link can no longer be used
+func (s *InvitationService) Revoke(ctx context.Context, id uint, actor *Actor) (*models.AdminInvitation, error) {
+	var invitation models.AdminInvitation
+	if err := s.db.First(&invitation, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, err
+	}
+
+	// Davetiye oluşturamayan yönetici onu iptal de edemez
+	if err := checkInvitation(invitation.Role, actor); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.AdminInvitation{}).
+			Where("id = ? AND status = ?", invitation.ID, models.InvitationPending).
+			Updates(map[string]interface{}{
+				"status":     models.InvitationRevoked,
+				"revoked_by": actor.ID,
+				"revoked_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvitationInvalid
+		}
+
+		return recordAuditEvent(tx, actor, auditEntry{
+			Action: models.AuditActionInvitationRevoked,
+			Details: map[string]interface{}{
+				"invitation_id": invitation.ID,
+				"role":          invitation.Role,
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	invitation.Status = models.InvitationRevoked
+	invitation.RevokedBy = &actor.ID
+	invitation.RevokedAt = &now
+	return &invitation, nil
+}
+
+// GetByToken returns the pending invitation a link points to
+func (s *InvitationService) GetByToken(token string) (*models.AdminInvitation, error) {
+	id, nonce, signature, ok := parseInvitationToken(token)
+	if !ok {
+		return nil, ErrInvitationInvalid
+	}
+
+	var invitation models.AdminInvitation
+	if err := s.db.First(&invitation, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvitationInvalid
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(invitation.NonceHash), []byte(hashVerificationCode(nonce))) != 1 ||
+		!hmac.Equal([]byte(signature), []byte(invitationSignature(&invitation, nonce))) {
+		return nil, ErrInvitationInvalid
+	}
+
+	if invitation.Status != models.InvitationPending {
+		return nil, ErrInvitationInvalid
+	}
+	if time.Now().After(invitation.ExpiresAt) {
+		return nil, ErrInvitationExpired
+	}
+
+	return &invitation, nil
+}
+
+// Accept uses an invitation. An existing account with the invited email is upgraded after
+// its password is confirmed; otherwise a new account is created with username and password.
+func (s *InvitationService) Accept(ctx context.Context, token, username, password, ip, requestID string) (*models.User, error) {
+	invitation, err := s.GetByToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var user models.User
+	existing := true
+	if err := s.db.Where("LOWER(email) = ?", invitation.Email).First(&user).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		existing = false
+	}
+
+	if existing {
+		if utils.ComparePassword(user.Password, password) != nil {
+			return nil, ErrInvalidCredentials
+		}
+		if user.Status != models.StatusActive {
+			return nil, ErrUserNotActive
+		}
+		if roleRanks[user.Role] >= roleRanks[invitation.Role] {
+			return nil, ErrInvitationNotApplicable
+		}
+	} else if username == "" || password == "" {
+		return nil, ErrInvitationSignup
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before := map[string]interface{}{}
+		if existing {
+			before = userSnapshot(&user)
+			user.Role = invitation.Role
+			if err := tx.Model(&user).Update("role", user.Role).Error; err != nil {
+				return err
+			}
+		} else {
+			if err := createInvitedUser(tx, &user, invitation, username, password); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		result := tx.Model(&models.AdminInvitation{}).
+			Where("id = ? AND status = ?", invitation.ID, models.InvitationPending).
+			Updates(map[string]interface{}{
+				"status":           models.InvitationAccepted,
+				"accepted_user_id": user.ID,
+				"accepted_at":      now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvitationInvalid
+		}
+
+		actor := &Actor{ID: user.ID, Role: user.Role, IP: ip, RequestID: requestID}
+		return recordUserChange(tx, actor, models.AuditActionInvitationAccepted, before, &user, map[string]interface{}{
+			"invitation_id":   invitation.ID,
+			"invited_by":      invitation.InvitedBy,
+			"account_created": !existing,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func createInvitedUser(tx *gorm.DB, user *models.User, invitation *models.AdminInvitation, username, password string) error {
+	var count int64
+	if err := tx.Model(&models.User{}).
+		Where("username = ? AND status <> ?", username, models.StatusFrozen).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrUsernameTaken
+	}
+
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	*user = models.User{
+		Username:      username,
+		Email:         invitation.Email,
+		Password:      hashedPassword,
+		Status:        models.StatusActive,
+		Role:          invitation.Role,
+		CreatedAt:     time.Now(),
+		LastLoginDate: time.Now(),
+	}
+	if err := tx.Create(user).Error; err != nil {
+		if strings.Contains(err.Error(), "idx_username_active") {
+			return ErrUsernameTaken
+		}
+		if strings.Contains(err.Error(), "idx_email_active") {
+			return ErrEmailTaken
+		}
+		return err
+	}
+	return nil
+}
+
+// checkInvitation verifies that the requester may invite someone with role
+func checkInvitation(role models.UserRole, requester *Actor) error {
+	if requester.Role != models.RoleAdmin && requester.Role != models.RoleSuperAdmin {
+		return ErrForbidden
+	}
+	if role == models.RoleSuperAdmin && !requester.IsRootAdmin {
+		return ErrForbidden
+	}
+	return nil
+}
+
+func markExpired(invitation *models.AdminInvitation) {
+	if invitation.Status == models.InvitationPending && time.Now().After(invitation.ExpiresAt) {
+		invitation.Status = models.InvitationExpired
+	}
+}
+
+// signInvitation builds the link token "<id>.<nonce>.<signature>"
+func signInvitation(invitation *models.AdminInvitation, nonce string) string {
+	return fmt.Sprintf("%d.%s.%s", invitation.ID, nonce, invitationSignature(invitation, nonce))
+}
+
+// invitationSignature signs the invitation fields, so a token cannot be reused for
+// another invitation even if the database rows were modified
+func invitationSignature(invitation *models.AdminInvitation, nonce string) string {
+	secret := os.Getenv("INVITATION_SECRET")
+	if secret == "" {
+		secret = os.Getenv("JWT_SECRET")
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.%s.%s.%s", invitation.ID, nonce, invitation.Email, invitation.Role)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func parseInvitationToken(token string) (uint, string, string, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0, "", "", false
+	}
+
+	id, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, "", "", false
+	}
+
+	return uint(id), parts[1], parts[2], true
+}
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidCIDR    = errors.New("invalid IP address or CIDR range")
+	ErrIPRuleNotFound = errors.New("ip rule not found")
+	ErrIPRuleLockout  = errors.New("rule would block your own IP address")
+)
+
+// compiledIPRule is an active rule kept in memory for matching requests
+type compiledIPRule struct {
+	ID        uint
+	Network   *net.IPNet
+	Action    string
+	Scope     string
+	ExpiresAt *time.Time
+}
+
+// IPRuleService manages IP/CIDR rules. Active rules are cached in memory so that
+// requests are matched without a database query; hits are counted in memory and
+// written by SyncRules.
+type IPRuleService struct {
+	db *gorm.DB
+
+	mu    sync.RWMutex
+	rules []compiledIPRule
+	hits  map[uint]int64
+}
+
+func NewIPRuleService(db *gorm.DB) *IPRuleService {
+	return &IPRuleService{
+		db:   db,
+		hits: make(map[uint]int64),
+	}
+}
+
+// Create adds a rule. A single address is stored as a /32 or /128 range.
+func (s *IPRuleService) Create(ctx context.Context, cidr, action, scope, reason string, expiresAt *time.Time, actor *Actor) (*models.IPRule, error) {
+	network, err := parseIPRuleCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Yönetici kendi erişimini kesmesin
+	if action == models.IPRuleBlock && scope == models.IPRuleScopeAll {
+		if ip := net.ParseIP(actor.IP); ip != nil && network.Contains(ip) {
+			return nil, ErrIPRuleLockout
+		}
+	}
+
+	rule := &models.IPRule{
+		CIDR:      network.String(),
+		Action:    action,
+		Scope:     scope,
+		Reason:    reason,
+		ExpiresAt: expiresAt,
+		CreatedBy: actor.ID,
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(rule).Error; err != nil {
+			return err
+		}
+
+		return recordAuditEvent(tx, actor, auditEntry{
+			Action: models.AuditActionIPRuleCreated,
+			Details: map[string]interface{}{
+				"rule_id":    rule.ID,
+				"cidr":       rule.CIDR,
+				"action":     rule.Action,
+				"scope":      rule.Scope,
+				"reason":     rule.Reason,
+				"expires_at": formatAuditTime(rule.ExpiresAt),
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reload(ctx); err != nil {
+		log.Printf("Failed to reload IP rules: %v", err)
+	}
+	return rule, nil
+}
+
+// List returns the rules, newest first. Expired rules are only included on request.
+// Hits that are not written yet are added to the counters.
+func (s *IPRuleService) List(includeExpired bool, page, limit int) ([]models.IPRule, int64, error) {
+	query := s.db.Model(&models.IPRule{})
+	if !includeExpired {
+		query = query.Where("expires_at IS NULL OR expires_at > ?", time.Now())
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rules []models.IPRule
+	if err := query.Order("id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&rules).Error; err != nil {
+		return nil, 0, err
+	}
+
+	s.mu.RLock()
+	for i := range rules {
+		rules[i].HitCount += s.hits[rules[i].ID]
+	}
+	s.mu.RUnlock()
+
+	return rules, total, nil
+}
+
+// Delete removes a rule
+func (s *IPRuleService) Delete(ctx context.Context, id uint, actor *Actor) error {
+	var rule models.IPRule
+	if err := s.db.WithContext(ctx).First(&rule, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrIPRuleNotFound
+		}
+		return err
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&rule).Error; err != nil {
+			return err
+		}
+
+		return recordAuditEvent(tx, actor, auditEntry{
+			Action: models.AuditActionIPRuleDeleted,
+			Details: map[string]interface{}{
+				"rule_id":   rule.ID,
+				"cidr":      rule.CIDR,
+				"action":    rule.Action,
+				"scope":     rule.Scope,
+				"hit_count": rule.HitCount,
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	delete(s.hits, rule.ID)
+	s.mu.Unlock()
+
+	if err := s.reload(ctx); err != nil {
+		log.Printf("Failed to reload IP rules: %v", err)
+	}
+	return nil
+}
+
+// IsBlocked reports whether requests from ip are blocked for scope. Rules of the
+// "all" scope apply to every scope and allow rules win over block rules.
+// middleware.IPFilter calls it for every request, so it only uses the in-memory cache.
+func (s *IPRuleService) IsBlocked(ip, scope string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	now := time.Now()
+	var blockID, allowID uint
+
+	s.mu.RLock()
+	for _, rule := range s.rules {
+		if rule.Scope != scope && rule.Scope != models.IPRuleScopeAll {
+			continue
+		}
+		if rule.ExpiresAt != nil && !rule.ExpiresAt.After(now) {
+			continue
+		}
+		if !rule.Network.Contains(parsed) {
+			continue
+		}
+		if rule.Action == models.IPRuleAllow && allowID == 0 {
+			allowID = rule.ID
+		} else if rule.Action == models.IPRuleBlock && blockID == 0 {
+			blockID = rule.ID
+		}
+	}
+	s.mu.RUnlock()
+
+	if blockID == 0 {
+		return false
+	}
+
+	// Sayaç isteği belirleyen kurala yazılır
+	s.mu.Lock()
+	if allowID != 0 {
+		s.hits[allowID]++
+	} else {
+		s.hits[blockID]++
+	}
+	s.mu.Unlock()
+
+	return allowID == 0
+}
+
+// SyncRules writes the counted hits and reloads the active rules. It runs as a job
+// so that rules created on another instance are picked up as well.
+func (s *IPRuleService) SyncRules(ctx context.Context) error {
+	s.mu.Lock()
+	hits := s.hits
+	s.hits = make(map[uint]int64)
+	s.mu.Unlock()
+
+	now := time.Now()
+	for id, count := range hits {
+		if err := s.db.WithContext(ctx).Model(&models.IPRule{}).Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"hit_count":   gorm.Expr("hit_count + ?", count),
+				"last_hit_at": now,
+			}).Error; err != nil {
+			// Henüz yazılmamış tüm sayaçlar bir sonraki çalıştırmaya bırakılır
+			s.mu.Lock()
+			for id, count := range hits {
+				s.hits[id] += count
+			}
+			s.mu.Unlock()
+			return err
+		}
+		delete(hits, id)
+	}
+
+	return s.reload(ctx)
+}
+
+// reload replaces the in-memory rules with the active rules from the database
+func (s *IPRuleService) reload(ctx context.Context) error {
+	var rules []models.IPRule
+	if err := s.db.WithContext(ctx).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).
+		Order("id").
+		Find(&rules).Error; err != nil {
+		return err
+	}
+
+	compiled := make([]compiledIPRule, 0, len(rules))
+	for _, rule := range rules {
+		_, network, err := net.ParseCIDR(rule.CIDR)
+		if err != nil {
+			log.Printf("Skipping IP rule %d with invalid CIDR %q", rule.ID, rule.CIDR)
+			continue
+		}
+		compiled = append(compiled, compiledIPRule{
+			ID:        rule.ID,
+			Network:   network,
+			Action:    rule.Action,
+			Scope:     rule.Scope,
+			ExpiresAt: rule.ExpiresAt,
+		})
+	}
+
+	s.mu.Lock()
+	s.rules = compiled
+	s.mu.Unlock()
+	return nil
+}
+
+// parseIPRuleCIDR accepts an address or a CIDR range and returns the network
+func parseIPRuleCIDR(value string) (*net.IPNet, error) {
+	value = strings.TrimSpace(value)
+	if !strings.Contains(value, "/") {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, ErrInvalidCIDR
+		}
+		if ip.To4() != nil {
+			value += "/32"
+		} else {
+			value += "/128"
+		}
+	}
+
+	_, network, err := net.ParseCIDR(value)
+	if err != nil {
+		return nil, ErrInvalidCIDR
+	}
+	return network, nil
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrInsufficientReputation = errors.New("insufficient reputation")
+
+var errUnknownPrivilege = errors.New("unknown privilege")
+
+// InsufficientReputationError tells which privilege is missing and the reputation it needs.
+// It matches ErrInsufficientReputation with errors.Is.
+type InsufficientReputationError struct {
+	Privilege string
+	Required  int
+}
+
+func (e *InsufficientReputationError) Error() string {
+	return fmt.Sprintf("%d reputation required for %s", e.Required, e.Privilege)
+}
+
+func (e *InsufficientReputationError) Is(target error) bool {
+	return target == ErrInsufficientReputation
+}
+
+// defaultPrivileges is the privilege table, thresholds can be changed with PRIVILEGE_THRESHOLDS
+var defaultPrivileges = []models.Privilege{
+	{Name: models.PrivilegeVoteUp, Description: "Upvote questions and answers", Reputation: 15},
+	{Name: models.PrivilegeCommentEverywhere, Description: "Comment on posts of other users", Reputation: 50},
+	{Name: models.PrivilegeVoteDown, Description: "Downvote questions and answers", Reputation: 125},
+	{Name: models.PrivilegeEditPosts, Description: "Edit questions and answers of other users", Reputation: 2000},
+	{Name: models.PrivilegeVoteToClose, Description: "Close and reopen questions of other users", Reputation: 3000},
+}
+
+// NextPrivilege is a privilege the user has not earned yet
+type NextPrivilege struct {
+	models.Privilege
+	Remaining int `json:"remaining"`
+}
+
+// UserPrivileges lists the earned and the next privileges of a user
+type UserPrivileges struct {
+	Reputation int                `json:"reputation"`
+	Earned     []models.Privilege `json:"earned"`
+	Next       []NextPrivilege    `json:"next"`
+}
+
+type PrivilegeService struct {
+	db *gorm.DB
+}
+
+func NewPrivilegeService(db *gorm.DB) *PrivilegeService {
+	return &PrivilegeService{db: db}
+}
+
+// Check reports whether a user has a privilege and the reputation it requires.
+// middleware.RequirePrivilege calls it.
+func (s *PrivilegeService) Check(userID uint, role, privilege string) (bool, int, error) {
+	err := checkPrivilege(s.db, &Actor{ID: userID, Role: models.UserRole(role)}, privilege)
+
+	var insufficient *InsufficientReputationError
+	if errors.As(err, &insufficient) {
+		return false, insufficient.Required, nil
+	}
+	if err != nil {
+		return false, 0, err
+	}
+
+	required, _ := privilegeThreshold(privilege)
+	return true, required, nil
+}
+
+// ForUser returns the privileges a user has earned by reputation and the ones still ahead,
+// lowest threshold first
+func (s *PrivilegeService) ForUser(userID uint) (*UserPrivileges, error) {
+	reputation, err := userReputation(s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &UserPrivileges{
+		Reputation: reputation,
+		Earned:     []models.Privilege{},
+		Next:       []NextPrivilege{},
+	}
+	for _, privilege := range privileges() {
+		if reputation >= privilege.Reputation {
+			result.Earned = append(result.Earned, privilege)
+		} else {
+			result.Next = append(result.Next, NextPrivilege{Privilege: privilege, Remaining: privilege.Reputation - reputation})
+		}
+	}
+	return result, nil
+}
+
+// checkPrivilege returns an InsufficientReputationError unless actor has earned privilege.
+// Admins moderate the site and have every privilege.
+func checkPrivilege(tx *gorm.DB, actor *Actor, privilege string) error {
+	required, ok := privilegeThreshold(privilege)
+	if !ok {
+		return errUnknownPrivilege
+	}
+	if isAdminRole(actor.Role) {
+		return nil
+	}
+
+	reputation, err := userReputation(tx, actor.ID)
+	if err != nil {
+		return err
+	}
+	if reputation < required {
+		return &InsufficientReputationError{Privilege: privilege, Required: required}
+	}
+	return nil
+}
+
+func userReputation(tx *gorm.DB, userID uint) (int, error) {
+	var user models.User
+	if err := tx.Unscoped().Select("id", "reputation").First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrUserNotFound
+		}
+		return 0, err
+	}
+	return user.Reputation, nil
+}
+
+func privilegeThreshold(name string) (int, bool) {
+	for _, privilege := range privileges() {
+		if privilege.Name == name {
+			return privilege.Reputation, true
+		}
+	}
+	return 0, false
+}
+
+// privileges returns the privilege table sorted by reputation. PRIVILEGE_THRESHOLDS
+// ("name:reputation,...") overrides single thresholds, invalid entries are logged and skipped.
+func privileges() []models.Privilege {
+	table := make([]models.Privilege, len(defaultPrivileges))
+	copy(table, defaultPrivileges)
+
+	if value := os.Getenv("PRIVILEGE_THRESHOLDS"); value != "" {
+		for _, entry := range strings.Split(value, ",") {
+			parts := strings.SplitN(strings.TrimSpace(entry), ":", 2)
+			if len(parts) != 2 {
+				log.Printf("Invalid privilege threshold %q", entry)
+				continue
+			}
+			reputation, err := strconv.Atoi(parts[1])
+			if err != nil || reputation < 0 {
+				log.Printf("Invalid privilege threshold %q", entry)
+				continue
+			}
+
+			found := false
+			for i := range table {
+				if table[i].Name == parts[0] {
+					table[i].Reputation = reputation
+					found = true
+				}
+			}
+			if !found {
+				log.Printf("Unknown privilege in threshold %q", entry)
+			}
+		}
+	}
+
+	sort.SliceStable(table, func(i, j int) bool { return table[i].Reputation < table[j].Reputation })
+	return table
+}
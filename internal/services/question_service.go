@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrQuestionNotFound = errors.New("question not found")
+
+// questionSortColumns maps the sort modes of ListQuestions to SQL expressions
+var questionSortColumns = map[string]string{
+	"newest": "questions.created_at DESC, questions.id DESC",
+	"oldest": "questions.created_at ASC, questions.id ASC",
+	"active": "questions.updated_at DESC, questions.id DESC",
+	"votes":  "questions.score DESC, questions.created_at DESC, questions.id DESC",
+}
+
+// QuestionListFilter contains the filters of the question listing. For a signed in
+// ViewerID, questions with an ignored tag are hidden unless Tag is set, and Following
+// limits the listing to followed tags.
+type QuestionListFilter struct {
+	AuthorID  uint
+	Status    models.QuestionStatus
+	Search    string
+	Tag       string
+	ViewerID  uint
+	Following bool
+	Sort      string
+	Page      int
+	Limit     int
+}
+
+// QuestionUpdate holds the fields of a question edit, nil fields are kept.
+// Summary describes the edit in the revision history.
+type QuestionUpdate struct {
+	Title   *string
+	Body    *string
+	Tags    *[]string
+	Status  *models.QuestionStatus
+	Summary string
+}
+
+// questionEdit holds the content changes of a question, nil fields are kept
+type questionEdit struct {
+	Title *string
+	Body  *string
+	Tags  *[]string
+}
+
+type QuestionService struct {
+	db *gorm.DB
+}
+
+func NewQuestionService(db *gorm.DB) *QuestionService {
+	return &QuestionService{db: db}
+}
+
+// Create asks a new question on behalf of actor. Tags that do not exist yet are created.
+func (s *QuestionService) Create(ctx context.Context, title, body string, tagNames []string, actor *Actor) (*models.Question, error) {
+	author, err := activeAuthor(s.db.WithContext(ctx), actor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	question := &models.Question{
+		Title:    strings.TrimSpace(title),
+		Body:     body,
+		AuthorID: author.ID,
+		Author:   *author,
+		Status:   models.QuestionOpen,
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tags, err := resolveTags(tx, tagNames)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Omit("Author", "Tags").Create(question).Error; err != nil {
+			return err
+		}
+		question.Tags = tags
+
+		if err := setQuestionTags(tx, question.ID, tags); err != nil {
+			return err
+		}
+		return recordQuestionRevision(tx, question.ID, revisionMeta{EditorID: author.ID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return question, nil
+}
+
+// Get returns a question with its author and the accepted answer
+func (s *QuestionService) Get(id uint) (*models.Question, error) {
+	var question models.Question
+	if err := s.db.Preload("Author", withDeletedUsers).
+		Preload("AcceptedAnswer.Author", withDeletedUsers).
+		Preload("Tags", orderTagsByName).
+		First(&question, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, err
+	}
+	return &question, nil
+}
+
+// List returns a page of questions with their authors
+func (s *QuestionService) List(filter QuestionListFilter) ([]models.Question, int64, error) {
+	order, ok := questionSortColumns[filter.Sort]
+	if !ok {
+		order = questionSortColumns["newest"]
+	}
+
+	query := s.db.Model(&models.Question{})
+	if filter.AuthorID != 0 {
+		query = query.Where("questions.author_id = ?", filter.AuthorID)
+	}
+	if filter.Status != "" {
+		query = query.Where("questions.status = ?", filter.Status)
+	}
+	if filter.Search != "" {
+		pattern := "%" + escapeLike(filter.Search) + "%"
+		query = query.Where("questions.title ILIKE ? OR questions.body ILIKE ?", pattern, pattern)
+	}
+	if filter.Tag != "" {
+		// Eş anlamlı bir isim hedef etiketi listeler, birleştirme sürerken iki etiket de eşleşir
+		tag := normalizeTagName(filter.Tag)
+		query = query.Where(`EXISTS (SELECT 1 FROM question_tags qt JOIN tags t ON t.id = qt.tag_id
+			WHERE qt.question_id = questions.id AND (t.name = ? OR t.id IN (
+				SELECT target_tag_id FROM tag_synonyms WHERE synonym = ? AND status = ?)))`,
+			tag, tag, models.TagSynonymApproved)
+	}
+	if filter.ViewerID != 0 && filter.Following {
+		query = query.Where(`EXISTS (SELECT 1 FROM question_tags qt JOIN user_tag_preferences p ON p.tag_id = qt.tag_id
+			WHERE qt.question_id = questions.id AND p.user_id = ? AND p.preference = ?)`, filter.ViewerID, models.TagFollow)
+	}
+	if filter.ViewerID != 0 && filter.Tag == "" {
+		query = query.Where(`NOT EXISTS (SELECT 1 FROM question_tags qt JOIN user_tag_preferences p ON p.tag_id = qt.tag_id
+			WHERE qt.question_id = questions.id AND p.user_id = ? AND p.preference = ?)`, filter.ViewerID, models.TagIgnore)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var questions []models.Question
+	if err := query.Preload("Author", withDeletedUsers).
+		Preload("Tags", orderTagsByName).
+		Order(order).
+		Offset((filter.Page - 1) * filter.Limit).
+		Limit(filter.Limit).
+		Find(&questions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return questions, total, nil
+}
+
+// Update edits a question. Besides the author and admins, users with the edit_posts
+// privilege may edit it and users with the vote_to_close privilege may close and reopen it.
+func (s *QuestionService) Update(ctx context.Context, id uint, update QuestionUpdate, actor *Actor) (*models.Question, error) {
+	question, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if update.Status != nil && !canManageContent(question.AuthorID, actor) {
+		if err := checkPrivilege(s.db.WithContext(ctx), actor, models.PrivilegeVoteToClose); err != nil {
+			return nil, err
+		}
+	}
+	// Sadece durumu değiştiren kullanıcının düzenleme yetkisi olması gerekmez
+	if update.Title != nil || update.Body != nil || update.Tags != nil {
+		if err := checkEditPermission(s.db.WithContext(ctx), question.AuthorID, actor); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := activeAuthor(s.db.WithContext(ctx), actor.ID); err != nil {
+		return nil, err
+	}
+
+	if update.Title == nil && update.Body == nil && update.Tags == nil && update.Status == nil {
+		return question, nil
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if update.Status != nil {
+			if err := tx.Model(&models.Question{}).Where("id = ?", question.ID).
+				Update("status", *update.Status).Error; err != nil {
+				return err
+			}
+		}
+		if update.Title == nil && update.Body == nil && update.Tags == nil {
+			return nil
+		}
+
+		edit := questionEdit{Title: update.Title, Body: update.Body, Tags: update.Tags}
+		return editQuestion(tx, question.ID, edit, revisionMeta{EditorID: actor.ID, Summary: update.Summary})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(id)
+}
+
+// Delete soft deletes a question. Deletions by admins are written to the audit log.
+func (s *QuestionService) Delete(ctx context.Context, id uint, actor *Actor) error {
+	question, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+	if !canManageContent(question.AuthorID, actor) {
+		return ErrForbidden
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockQuestion(tx, question.ID); err != nil {
+			return err
+		}
+		// Silinen sorular etiket sayılarına dahil edilmez
+		if err := setQuestionTags(tx, question.ID, nil); err != nil {
+			return err
+		}
+		if err := tx.Delete(question).Error; err != nil {
+			return err
+		}
+
+		if question.AuthorID == actor.ID {
+			return nil
+		}
+		return recordAuditEvent(tx, actor, auditEntry{
+			Action:       models.AuditActionQuestionDeleted,
+			TargetUserID: question.AuthorID,
+			Details: map[string]interface{}{
+				"question_id": question.ID,
+			},
+		})
+	})
+}
+
+// editQuestion changes the content of a question and records the result as a new
+// revision. Suggested edits and rollbacks go through it as well.
+func editQuestion(tx *gorm.DB, id uint, edit questionEdit, meta revisionMeta) error {
+	// Eşzamanlı düzenlemeler etiket sayılarını ve revizyon numaralarını bozmasın
+	if _, err := lockQuestion(tx, id); err != nil {
+		return err
+	}
+
+	changes := map[string]interface{}{
+		// Yalnızca etiket değiştiğinde de soru düzenlenmiş sayılır
+		"updated_at": time.Now(),
+	}
+	if edit.Title != nil {
+		changes["title"] = strings.TrimSpace(*edit.Title)
+	}
+	if edit.Body != nil {
+		changes["body"] = *edit.Body
+	}
+	if edit.Tags != nil {
+		tags, err := resolveTags(tx, *edit.Tags)
+		if err != nil {
+			return err
+		}
+		if err := setQuestionTags(tx, id, tags); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Model(&models.Question{}).Where("id = ?", id).Updates(changes).Error; err != nil {
+		return err
+	}
+	return recordQuestionRevision(tx, id, meta)
+}
+
+// orderTagsByName preloads the tags of questions in alphabetical order
+func orderTagsByName(db *gorm.DB) *gorm.DB {
+	return db.Omit("wiki").Order("tags.name")
+}
+
+// withDeletedUsers preloads authors even when their account is frozen or deleted,
+// their content stays visible
+func withDeletedUsers(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
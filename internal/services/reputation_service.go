@@ -0,0 +1,274 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// defaultReputationDailyCap is used when REPUTATION_DAILY_CAP is not set
+const defaultReputationDailyCap = 200
+
+// reputationPoints is the reputation change of every reason. Bounties are not
+// supported yet and have no reason in the ledger.
+var reputationPoints = map[models.ReputationReason]int{
+	models.ReputationQuestionUpvoted:   10,
+	models.ReputationQuestionDownvoted: -2,
+	models.ReputationAnswerUpvoted:     10,
+	models.ReputationAnswerDownvoted:   -2,
+	models.ReputationDownvoteCast:      -1,
+	models.ReputationAnswerAccepted:    15,
+	models.ReputationAcceptedAnswer:    2,
+}
+
+// cappedReputationReasons count towards the daily cap, accepted answers do not
+var cappedReputationReasons = []models.ReputationReason{
+	models.ReputationQuestionUpvoted,
+	models.ReputationAnswerUpvoted,
+}
+
+type ReputationService struct {
+	db *gorm.DB
+}
+
+func NewReputationService(db *gorm.DB) *ReputationService {
+	return &ReputationService{db: db}
+}
+
+// History returns the current reputation of a user and a page of ledger entries, newest first
+func (s *ReputationService) History(userID uint, page, limit int) (int, []models.ReputationEvent, int64, error) {
+	var user models.User
+	if err := s.db.Unscoped().Select("id", "reputation").First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil, 0, ErrUserNotFound
+		}
+		return 0, nil, 0, err
+	}
+
+	query := s.db.Model(&models.ReputationEvent{}).Where("user_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, nil, 0, err
+	}
+
+	var events []models.ReputationEvent
+	if err := query.Order("created_at DESC, id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return 0, nil, 0, err
+	}
+
+	return user.Reputation, events, total, nil
+}
+
+// recordVoteReputation writes the ledger entries of a new vote. authorID is the author of the voted post.
+func recordVoteReputation(tx *gorm.DB, vote *models.Vote, authorID uint) error {
+	var entries []models.ReputationEvent
+	newEntry := func(userID uint, reason models.ReputationReason) models.ReputationEvent {
+		return models.ReputationEvent{
+			UserID:   userID,
+			Reason:   reason,
+			PostType: &vote.PostType,
+			PostID:   &vote.PostID,
+			VoteID:   &vote.ID,
+			ActorID:  &vote.UserID,
+		}
+	}
+
+	switch {
+	case vote.PostType == models.PostTypeQuestion && vote.Value == models.VoteUp:
+		entries = append(entries, newEntry(authorID, models.ReputationQuestionUpvoted))
+	case vote.PostType == models.PostTypeQuestion && vote.Value == models.VoteDown:
+		entries = append(entries, newEntry(authorID, models.ReputationQuestionDownvoted))
+	case vote.PostType == models.PostTypeAnswer && vote.Value == models.VoteUp:
+		entries = append(entries, newEntry(authorID, models.ReputationAnswerUpvoted))
+	case vote.PostType == models.PostTypeAnswer && vote.Value == models.VoteDown:
+		entries = append(entries,
+			newEntry(authorID, models.ReputationAnswerDownvoted),
+			newEntry(vote.UserID, models.ReputationDownvoteCast))
+	}
+
+	return addReputation(tx, entries)
+}
+
+// reverseVoteReputation reverses the ledger entries of a vote that is changed or retracted
+func reverseVoteReputation(tx *gorm.DB, voteID uint) error {
+	return reverseReputation(tx, func(db *gorm.DB) *gorm.DB {
+		return db.Where("vote_id = ?", voteID)
+	})
+}
+
+// recordAcceptReputation writes the ledger entries of an accepted answer.
+// Accepting one's own answer earns nothing.
+func recordAcceptReputation(tx *gorm.DB, question *models.Question, answer *models.Answer) error {
+	if answer.AuthorID == question.AuthorID {
+		return nil
+	}
+
+	postType := models.PostTypeAnswer
+	return addReputation(tx, []models.ReputationEvent{
+		{
+			UserID:   answer.AuthorID,
+			Reason:   models.ReputationAnswerAccepted,
+			PostType: &postType,
+			PostID:   &answer.ID,
+			ActorID:  &question.AuthorID,
+		},
+		{
+			UserID:   question.AuthorID,
+			Reason:   models.ReputationAcceptedAnswer,
+			PostType: &postType,
+			PostID:   &answer.ID,
+			ActorID:  &question.AuthorID,
+		},
+	})
+}
+
+// reverseAcceptReputation reverses the ledger entries of an answer that is no longer accepted
+func reverseAcceptReputation(tx *gorm.DB, answerID uint) error {
+	return reverseReputation(tx, func(db *gorm.DB) *gorm.DB {
+		return db.Where("post_type = ? AND post_id = ? AND reason IN ?", models.PostTypeAnswer, answerID,
+			[]models.ReputationReason{models.ReputationAnswerAccepted, models.ReputationAcceptedAnswer})
+	})
+}
+
+// addReputation applies the points and the daily cap to entries, appends them to the
+// ledger and updates the cached reputation of the users
+func addReputation(tx *gorm.DB, entries []models.ReputationEvent) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	userIDs := make([]uint, 0, len(entries))
+	for _, entry := range entries {
+		userIDs = append(userIDs, entry.UserID)
+	}
+	if err := lockReputationUsers(tx, userIDs); err != nil {
+		return err
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		entry.Delta = reputationPoints[entry.Reason]
+		if err := applyDailyCap(tx, entry); err != nil {
+			return err
+		}
+		if err := appendReputationEvent(tx, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reverseReputation appends a reversal for every entry matched by scope that is not
+// a reversal itself and has not been reversed yet
+func reverseReputation(tx *gorm.DB, scope func(*gorm.DB) *gorm.DB) error {
+	var events []models.ReputationEvent
+	if err := tx.Scopes(scope).
+		Where("reversal_of IS NULL").
+		Where("NOT EXISTS (SELECT 1 FROM reputation_events r WHERE r.reversal_of = reputation_events.id)").
+		Order("id").
+		Find(&events).Error; err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	userIDs := make([]uint, 0, len(events))
+	for _, event := range events {
+		userIDs = append(userIDs, event.UserID)
+	}
+	if err := lockReputationUsers(tx, userIDs); err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		reversal := models.ReputationEvent{
+			UserID:     event.UserID,
+			Delta:      -event.Delta,
+			Reason:     event.Reason,
+			PostType:   event.PostType,
+			PostID:     event.PostID,
+			VoteID:     event.VoteID,
+			ActorID:    event.ActorID,
+			ReversalOf: &event.ID,
+		}
+		if err := appendReputationEvent(tx, &reversal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyDailyCap lowers a positive delta so that the capped reasons of the current UTC day
+// do not exceed REPUTATION_DAILY_CAP. A fully capped entry is kept with a delta of 0.
+func applyDailyCap(tx *gorm.DB, entry *models.ReputationEvent) error {
+	if entry.Delta <= 0 || !isCappedReputationReason(entry.Reason) {
+		return nil
+	}
+
+	limit := utils.GetEnvInt("REPUTATION_DAILY_CAP", defaultReputationDailyCap)
+	if limit <= 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var earned int
+	if err := tx.Model(&models.ReputationEvent{}).
+		Where("user_id = ? AND reason IN ? AND created_at >= ?", entry.UserID, cappedReputationReasons, dayStart).
+		Select("COALESCE(SUM(delta), 0)").
+		Scan(&earned).Error; err != nil {
+		return err
+	}
+
+	remaining := limit - earned
+	if remaining < 0 {
+		remaining = 0
+	}
+	if entry.Delta > remaining {
+		entry.Delta = remaining
+	}
+	return nil
+}
+
+func isCappedReputationReason(reason models.ReputationReason) bool {
+	for _, capped := range cappedReputationReasons {
+		if capped == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// appendReputationEvent writes a ledger entry and adds its delta to the cached reputation.
+// Frozen and deleted accounts keep collecting reputation for their posts.
+func appendReputationEvent(tx *gorm.DB, event *models.ReputationEvent) error {
+	if err := tx.Create(event).Error; err != nil {
+		return err
+	}
+	if event.Delta == 0 {
+		return nil
+	}
+	return tx.Unscoped().Model(&models.User{}).Where("id = ?", event.UserID).
+		UpdateColumn("reputation", gorm.Expr("reputation + ?", event.Delta)).Error
+}
+
+// lockReputationUsers locks the rows of the users whose reputation changes. Rows are
+// locked in ID order so that concurrent votes cannot deadlock on each other.
+func lockReputationUsers(tx *gorm.DB, userIDs []uint) error {
+	var locked []uint
+	return tx.Unscoped().Model(&models.User{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", userIDs).
+		Order("id").
+		Pluck("id", &locked).Error
+}
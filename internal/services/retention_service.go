@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultAccountRetentionPeriod is used when ACCOUNT_RETENTION_PERIOD is not set
+	defaultAccountRetentionPeriod = 30 * 24 * time.Hour
+
+	anonymizationBatchSize = 100
+	erasureReasonRetention = "retention_period_expired"
+)
+
+// anonymizedFields lists the user columns overwritten during anonymization
+var anonymizedFields = []string{"username", "email", "password", "avatar", "ban_reason", "frozen_reason"}
+
+// personalRecordTables lists tables whose rows belong to a single user (user_id column)
+// and are removed when the account is anonymized
+var personalRecordTables = []string{"data_exports", "root_admin_challenges", "user_status_history", "account_reactivation_tokens", "user_warnings", "user_tag_preferences"}
+
+type RetentionService struct {
+	db *gorm.DB
+}
+
+func NewRetentionService(db *gorm.DB) *RetentionService {
+	return &RetentionService{db: db}
+}
+
+// AnonymizeDeletedAccounts irreversibly erases the personal data of accounts deleted
+// through DeleteAccount once the retention period is over. Frozen accounts are kept
+// since they can still be reactivated or restored.
+func (s *RetentionService) AnonymizeDeletedAccounts(ctx context.Context) error {
+	retention := utils.GetEnvDuration("ACCOUNT_RETENTION_PERIOD", defaultAccountRetentionPeriod)
+	cutoff := time.Now().Add(-retention)
+
+	for {
+		var users []models.User
+		if err := s.db.WithContext(ctx).Unscoped().
+			Where("deleted_at IS NOT NULL AND deleted_at < ? AND frozen_date IS NULL AND anonymized_at IS NULL", cutoff).
+			Order("id").
+			Limit(anonymizationBatchSize).
+			Find(&users).Error; err != nil {
+			return err
+		}
+
+		for i := range users {
+			if err := s.anonymize(ctx, &users[i]); err != nil {
+				return fmt.Errorf("anonymizing user %d: %w", users[i].ID, err)
+			}
+			log.Printf("User %d anonymized after retention period", users[i].ID)
+		}
+
+		if len(users) < anonymizationBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *RetentionService) anonymize(ctx context.Context, user *models.User) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// Kullanıcı adı ve e-posta benzersiz kalmalı, bu yüzden ID ile yer tutucu üret
+		err := tx.Unscoped().Model(user).Updates(map[string]interface{}{
+			"username":      fmt.Sprintf("deleted_user_%d", user.ID),
+			"email":         fmt.Sprintf("deleted_user_%d@anonymized.invalid", user.ID),
+			"password":      "",
+			"avatar":        "/uploads/default/avatar.png",
+			"ban_reason":    "",
+			"frozen_reason": "",
+			"anonymized_at": now,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		// Kabul edilen davetiyedeki e-posta da hesaptaki gibi yer tutucu ile değiştirilir
+		if err := tx.Model(&models.AdminInvitation{}).
+			Where("accepted_user_id = ?", user.ID).
+			Update("email", fmt.Sprintf("deleted_user_%d@anonymized.invalid", user.ID)).Error; err != nil {
+			return err
+		}
+
+		// Arşiv dosyaları satırlarla birlikte silinmeli, aksi halde diskte sahipsiz kalırlar
+		var exports []models.DataExport
+		if err := tx.Where("user_id = ? AND file_path <> ''", user.ID).Find(&exports).Error; err != nil {
+			return err
+		}
+		for i := range exports {
+			if err := os.Remove(exports[i].FilePath); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+
+		recordsDeleted := map[string]int64{}
+		for _, table := range personalRecordTables {
+			result := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE user_id = ?", table), user.ID)
+			if result.Error != nil {
+				return result.Error
+			}
+			recordsDeleted[table] = result.RowsAffected
+		}
+
+		encoded, err := json.Marshal(recordsDeleted)
+		if err != nil {
+			return err
+		}
+
+		tombstone := models.ErasureTombstone{
+			UserID:         user.ID,
+			DeletedAt:      user.DeletedAt.Time,
+			ErasedAt:       now,
+			ErasedFields:   strings.Join(anonymizedFields, ","),
+			RecordsDeleted: string(encoded),
+			Reason:         erasureReasonRetention,
+		}
+		if err := tx.Create(&tombstone).Error; err != nil {
+			return err
+		}
+
+		// Sistem tarafından yapılan işlem, aktör yok
+		return recordAuditEvent(tx, nil, auditEntry{
+			Action:       models.AuditActionUserAnonymized,
+			TargetUserID: user.ID,
+			Details: map[string]interface{}{
+				"tombstone_id": tombstone.ID,
+				"reason":       erasureReasonRetention,
+			},
+		})
+	})
+}
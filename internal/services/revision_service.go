@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRevisionNotFound  = errors.New("revision not found")
+	ErrRevisionIsCurrent = errors.New("revision is the current revision")
+)
+
+// revisionMeta describes who made an edit and why
+type revisionMeta struct {
+	EditorID   uint
+	Summary    string
+	RollbackOf *int
+}
+
+// RevisionDiff is the word-level difference between two revisions of a post.
+// Title and Tags are only set for questions; To is 0 for the diff of a suggested edit.
+type RevisionDiff struct {
+	From  int           `json:"from"`
+	To    int           `json:"to,omitempty"`
+	Title []DiffSegment `json:"title,omitempty"`
+	Body  []DiffSegment `json:"body"`
+	Tags  []DiffSegment `json:"tags,omitempty"`
+}
+
+type RevisionService struct {
+	db *gorm.DB
+}
+
+func NewRevisionService(db *gorm.DB) *RevisionService {
+	return &RevisionService{db: db}
+}
+
+// List returns a page of the revisions of a post, newest first
+func (s *RevisionService) List(postType models.PostType, postID uint, page, limit int) ([]models.PostRevision, int64, error) {
+	if _, err := postAuthorID(s.db, postType, postID); err != nil {
+		return nil, 0, err
+	}
+
+	query := s.db.Model(&models.PostRevision{}).Where("post_type = ? AND post_id = ?", postType, postID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var revisions []models.PostRevision
+	if err := query.Preload("Editor", withDeletedUsers).
+		Order("revision DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&revisions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return revisions, total, nil
+}
+
+// Get returns a single revision of a post
+func (s *RevisionService) Get(postType models.PostType, postID uint, revision int) (*models.PostRevision, error) {
+	if _, err := postAuthorID(s.db, postType, postID); err != nil {
+		return nil, err
+	}
+	return findRevision(s.db.Preload("Editor", withDeletedUsers), postType, postID, revision)
+}
+
+// Diff compares two revisions of a post word by word
+func (s *RevisionService) Diff(postType models.PostType, postID uint, from, to int) (*RevisionDiff, error) {
+	if _, err := postAuthorID(s.db, postType, postID); err != nil {
+		return nil, err
+	}
+
+	old, err := findRevision(s.db, postType, postID, from)
+	if err != nil {
+		return nil, err
+	}
+	current, err := findRevision(s.db, postType, postID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffRevisions(old, current), nil
+}
+
+// Rollback restores the content of an earlier revision as a new revision. The author
+// of the post, editors and admins can roll back.
+func (s *RevisionService) Rollback(ctx context.Context, postType models.PostType, postID uint, revision int, actor *Actor) (*models.PostRevision, error) {
+	authorID, err := postAuthorID(s.db.WithContext(ctx), postType, postID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.ID != authorID && !isEditorRole(actor.Role) {
+		return nil, ErrForbidden
+	}
+	if _, err := activeAuthor(s.db.WithContext(ctx), actor.ID); err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Gönderi kilitlenir, geri alma eşzamanlı bir düzenlemeyle yarışmasın
+		if _, err := lockPost(tx, postType, postID); err != nil {
+			return err
+		}
+
+		target, err := findRevision(tx, postType, postID, revision)
+		if err != nil {
+			return err
+		}
+		latest, err := latestRevision(tx, postType, postID)
+		if err != nil {
+			return err
+		}
+		if latest != nil && latest.Revision == target.Revision {
+			return ErrRevisionIsCurrent
+		}
+
+		meta := revisionMeta{
+			EditorID:   actor.ID,
+			Summary:    fmt.Sprintf("Rolled back to revision %d", target.Revision),
+			RollbackOf: &target.Revision,
+		}
+		if postType == models.PostTypeAnswer {
+			return editAnswer(tx, postID, target.Body, meta)
+		}
+
+		edit := questionEdit{Title: &target.Title, Body: &target.Body}
+		// Etiketlerden önceki revizyonlarda etiket bulunmaz, mevcut etiketler korunur
+		if tags := target.TagNames(); len(tags) > 0 {
+			edit.Tags = &tags
+		}
+		return editQuestion(tx, postID, edit, meta)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := latestRevision(s.db.Preload("Editor", withDeletedUsers), postType, postID)
+	if err != nil {
+		return nil, err
+	}
+	return latest, nil
+}
+
+// diffRevisions compares the content of two revisions of the same post
+func diffRevisions(from, to *models.PostRevision) *RevisionDiff {
+	diff := &RevisionDiff{
+		From: from.Revision,
+		To:   to.Revision,
+		Body: wordDiff(from.Body, to.Body),
+	}
+	if from.PostType == models.PostTypeQuestion {
+		diff.Title = wordDiff(from.Title, to.Title)
+		diff.Tags = wordDiff(from.Tags, to.Tags)
+	}
+	return diff
+}
+
+// appendRevision stores the content of a post as its next revision. An edit that
+// leaves the content unchanged does not create a revision.
+func appendRevision(tx *gorm.DB, revision *models.PostRevision) error {
+	latest, err := latestRevision(tx, revision.PostType, revision.PostID)
+	if err != nil {
+		return err
+	}
+
+	revision.Revision = 1
+	if latest != nil {
+		if latest.Title == revision.Title && latest.Body == revision.Body && latest.Tags == revision.Tags {
+			return nil
+		}
+		revision.Revision = latest.Revision + 1
+	}
+	return tx.Omit("Editor").Create(revision).Error
+}
+
+// recordQuestionRevision stores the current title, body and tags of a question as a revision
+func recordQuestionRevision(tx *gorm.DB, questionID uint, meta revisionMeta) error {
+	var question models.Question
+	if err := tx.Select("id", "title", "body").First(&question, questionID).Error; err != nil {
+		return err
+	}
+
+	var tags []string
+	if err := tx.Table("question_tags").
+		Joins("JOIN tags ON tags.id = question_tags.tag_id").
+		Where("question_tags.question_id = ?", questionID).
+		Order("tags.name").
+		Pluck("tags.name", &tags).Error; err != nil {
+		return err
+	}
+
+	return appendRevision(tx, &models.PostRevision{
+		PostType:   models.PostTypeQuestion,
+		PostID:     questionID,
+		Title:      question.Title,
+		Body:       question.Body,
+		Tags:       strings.Join(tags, " "),
+		EditorID:   meta.EditorID,
+		Summary:    meta.Summary,
+		RollbackOf: meta.RollbackOf,
+	})
+}
+
+// recordAnswerRevision stores the current body of an answer as a revision
+func recordAnswerRevision(tx *gorm.DB, answerID uint, meta revisionMeta) error {
+	var answer models.Answer
+	if err := tx.Select("id", "body").First(&answer, answerID).Error; err != nil {
+		return err
+	}
+
+	return appendRevision(tx, &models.PostRevision{
+		PostType:   models.PostTypeAnswer,
+		PostID:     answerID,
+		Body:       answer.Body,
+		EditorID:   meta.EditorID,
+		Summary:    meta.Summary,
+		RollbackOf: meta.RollbackOf,
+	})
+}
+
+func findRevision(tx *gorm.DB, postType models.PostType, postID uint, revision int) (*models.PostRevision, error) {
+	var rev models.PostRevision
+	if err := tx.Where("post_type = ? AND post_id = ? AND revision = ?", postType, postID, revision).
+		First(&rev).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// latestRevision returns the newest revision of a post, nil if it has none
+func latestRevision(tx *gorm.DB, postType models.PostType, postID uint) (*models.PostRevision, error) {
+	var rev models.PostRevision
+	if err := tx.Where("post_type = ? AND post_id = ?", postType, postID).
+		Order("revision DESC").
+		First(&rev).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// postAuthorID returns the author of a question or an answer
+func postAuthorID(tx *gorm.DB, postType models.PostType, postID uint) (uint, error) {
+	switch postType {
+	case models.PostTypeQuestion:
+		var question models.Question
+		if err := tx.Select("id", "author_id").First(&question, postID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return 0, ErrQuestionNotFound
+			}
+			return 0, err
+		}
+		return question.AuthorID, nil
+	case models.PostTypeAnswer:
+		var answer models.Answer
+		if err := tx.Select("id", "author_id").First(&answer, postID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return 0, ErrAnswerNotFound
+			}
+			return 0, err
+		}
+		return answer.AuthorID, nil
+	}
+	return 0, errUnknownPostType
+}
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/pkg/mailer"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrNotRootAdmin        = errors.New("only root admins can perform this action")
+	ErrLastRootAdmin       = errors.New("the last root admin cannot be removed")
+	ErrAlreadyRootAdmin    = errors.New("user is already a root admin")
+	ErrTargetNotRootAdmin  = errors.New("user is not a root admin")
+	ErrRootAdminTarget     = errors.New("root status can only be given to active SUPER_ADMIN accounts")
+	ErrInvalidVerification = errors.New("invalid password or verification code")
+)
+
+const (
+	// defaultRootAdminCodeTTL is used when ROOT_ADMIN_CODE_TTL is not set
+	defaultRootAdminCodeTTL = 10 * time.Minute
+
+	rootAdminCodeDigits      = 6
+	rootAdminCodeMaxAttempts = 5
+
+	// rootAdminAttemptWindow is the period in which failed attempts are counted,
+	// requesting a new code does not reset them
+	rootAdminAttemptWindow = time.Hour
+)
+
+// RootAdminService manages root admin status. Every change needs the acting root
+// admin's password and a one-time code sent to their email address.
+type RootAdminService struct {
+	db *gorm.DB
+}
+
+func NewRootAdminService(db *gorm.DB) *RootAdminService {
+	return &RootAdminService{db: db}
+}
+
+// ListRootAdmins root admin yetkisine sahip kullanıcıları listeler
+func (s *RootAdminService) ListRootAdmins() ([]models.User, error) {
+	var users []models.User
+	if err := s.db.Where("is_root_admin = ?", true).Order("id").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// SendVerificationCode emails a one-time code to the acting root admin
+func (s *RootAdminService) SendVerificationCode(ctx context.Context, actor *Actor) (*models.RootAdminChallenge, error) {
+	user, err := s.findRootAdmin(actor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := utils.GenerateNumericCode(rootAdminCodeDigits)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := utils.GetEnvDuration("ROOT_ADMIN_CODE_TTL", defaultRootAdminCodeTTL)
+	challenge := &models.RootAdminChallenge{
+		UserID:    user.ID,
+		CodeHash:  hashVerificationCode(code),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.db.WithContext(ctx).Create(challenge).Error; err != nil {
+		return nil, err
+	}
+
+	body := fmt.Sprintf("Hello %s,\n\nYour verification code for changing root admin status is %s.\n\nThe code expires in %s. If you did not request it, secure your account immediately.\n",
+		user.Username, code, ttl)
+	if err := mailer.Send(user.Email, "Root admin verification code", body); err != nil {
+		return nil, err
+	}
+
+	return challenge, nil
+}
+
+// Grant gives root status to another SUPER_ADMIN
+func (s *RootAdminService) Grant(ctx context.Context, targetID uint, password, code string, actor *Actor) error {
+	if err := s.verify(ctx, actor.ID, password, code); err != nil {
+		return err
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockRootAdmins(tx); err != nil {
+			return err
+		}
+		return setRootAdmin(tx, targetID, true, actor, nil)
+	})
+}
+
+// Transfer gives root status to another SUPER_ADMIN and removes it from the acting root admin
+func (s *RootAdminService) Transfer(ctx context.Context, targetID uint, password, code string, actor *Actor) error {
+	if targetID == actor.ID {
+		return ErrAlreadyRootAdmin
+	}
+	if err := s.verify(ctx, actor.ID, password, code); err != nil {
+		return err
+	}
+
+	details := map[string]interface{}{"transfer": true}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockRootAdmins(tx); err != nil {
+			return err
+		}
+		if err := setRootAdmin(tx, targetID, true, actor, details); err != nil {
+			return err
+		}
+		return setRootAdmin(tx, actor.ID, false, actor, details)
+	})
+}
+
+// Revoke removes root status from a root admin, which can be the acting one.
+// The last remaining root admin can never lose root status.
+func (s *RootAdminService) Revoke(ctx context.Context, targetID uint, password, code string, actor *Actor) error {
+	if err := s.verify(ctx, actor.ID, password, code); err != nil {
+		return err
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		roots, err := lockRootAdmins(tx)
+		if err != nil {
+			return err
+		}
+		if roots <= 1 {
+			return ErrLastRootAdmin
+		}
+		return setRootAdmin(tx, targetID, false, actor, nil)
+	})
+}
+
+// BreakGlassGrant makes the given account a root admin without any verification.
+// It is only reachable from the rootadmin command, for recovering when every
+// root admin has lost access.
+func (s *RootAdminService) BreakGlassGrant(ctx context.Context, identifier, reason string) (*models.User, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).Where("email = ? OR username = ?", identifier, identifier).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before := userSnapshot(&user)
+		user.IsRootAdmin = true
+		user.Role = models.RoleSuperAdmin
+
+		// Kurtarılan hesap pasif veya banlı ise sistem tarafından etkinleştirilir
+		switch user.Status {
+		case models.StatusPassive:
+			if err := transitionStatus(tx, &user, models.StatusEventActivate, nil, reason); err != nil {
+				return err
+			}
+		case models.StatusBanned:
+			user.BanReason = ""
+			user.BanEndDate = nil
+			if err := transitionStatus(tx, &user, models.StatusEventUnban, nil, reason); err != nil {
+				return err
+			}
+		}
+		if err := tx.Save(&user).Error; err != nil {
+			return err
+		}
+
+		return recordUserChange(tx, nil, models.AuditActionRootGranted, before, &user, map[string]interface{}{
+			"source": "break_glass_cli",
+			"reason": reason,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// verify checks the password and the latest verification code of a root admin.
+// Failed attempts are counted outside of the caller's transaction and limited per
+// user within rootAdminAttemptWindow.
+func (s *RootAdminService) verify(ctx context.Context, userID uint, password, code string) error {
+	user, err := s.findRootAdmin(userID)
+	if err != nil {
+		return err
+	}
+
+	var challenge models.RootAdminChallenge
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ? AND used_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("id DESC").
+		First(&challenge).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidVerification
+		}
+		return err
+	}
+
+	// Hatalı denemeler koda değil kullanıcıya göre sayılır, yeni kod istemek sayacı sıfırlamaz
+	var attempts int64
+	if err := s.db.WithContext(ctx).Model(&models.RootAdminChallenge{}).
+		Where("user_id = ? AND created_at > ?", userID, time.Now().Add(-rootAdminAttemptWindow)).
+		Select("COALESCE(SUM(attempts), 0)").
+		Scan(&attempts).Error; err != nil {
+		return err
+	}
+	if attempts >= rootAdminCodeMaxAttempts {
+		return ErrInvalidVerification
+	}
+
+	codeMatches := subtle.ConstantTimeCompare([]byte(challenge.CodeHash), []byte(hashVerificationCode(code))) == 1
+	if utils.ComparePassword(user.Password, password) != nil || !codeMatches {
+		if err := s.db.WithContext(ctx).Model(&challenge).
+			UpdateColumn("attempts", gorm.Expr("attempts + 1")).Error; err != nil {
+			log.Printf("Failed to count verification attempt for user %d: %v", userID, err)
+		}
+		return ErrInvalidVerification
+	}
+
+	// Kod tek kullanımlıktır
+	result := s.db.WithContext(ctx).Model(&models.RootAdminChallenge{}).
+		Where("id = ? AND used_at IS NULL", challenge.ID).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvalidVerification
+	}
+
+	return nil
+}
+
+func (s *RootAdminService) findRootAdmin(userID uint) (*models.User, error) {
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	if !user.IsRootAdmin || user.Status != models.StatusActive {
+		return nil, ErrNotRootAdmin
+	}
+	return &user, nil
+}
+
+// lockRootAdmins locks the root admin rows so concurrent revokes cannot remove
+// the last root admin, and returns how many there are
+func lockRootAdmins(tx *gorm.DB) (int, error) {
+	var ids []uint
+	if err := tx.Model(&models.User{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("is_root_admin = ?", true).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+func setRootAdmin(tx *gorm.DB, userID uint, isRoot bool, actor *Actor, details map[string]interface{}) error {
+	var user models.User
+	if err := tx.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	action := models.AuditActionRootRevoked
+	if isRoot {
+		if user.IsRootAdmin {
+			return ErrAlreadyRootAdmin
+		}
+		if user.Role != models.RoleSuperAdmin || user.Status != models.StatusActive {
+			return ErrRootAdminTarget
+		}
+		action = models.AuditActionRootGranted
+	} else if !user.IsRootAdmin {
+		return ErrTargetNotRootAdmin
+	}
+
+	before := userSnapshot(&user)
+	user.IsRootAdmin = isRoot
+	if err := tx.Model(&user).Update("is_root_admin", isRoot).Error; err != nil {
+		return err
+	}
+
+	return recordUserChange(tx, actor, action, before, &user, details)
+}
+
+func hashVerificationCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidStatusTransition = errors.New("status transition is not allowed")
+	ErrStatusReasonRequired    = errors.New("a reason is required for this status change")
+)
+
+// statusTransition describes one event of the account status state machine
+type statusTransition struct {
+	From           []models.UserStatus
+	To             models.UserStatus
+	Triggers       []string
+	RequiresReason bool
+}
+
+// statusTransitions is the single source of truth for account status changes.
+// Every code path that changes User.Status goes through transitionStatus.
+var statusTransitions = map[models.StatusEvent]statusTransition{
+	models.StatusEventActivate: {
+		From:     []models.UserStatus{models.StatusPassive},
+		To:       models.StatusActive,
+		Triggers: []string{models.StatusTriggerSelf, models.StatusTriggerAdmin, models.StatusTriggerSystem},
+	},
+	models.StatusEventDeactivate: {
+		From:     []models.UserStatus{models.StatusActive},
+		To:       models.StatusPassive,
+		Triggers: []string{models.StatusTriggerSelf, models.StatusTriggerAdmin},
+	},
+	models.StatusEventPassivate: {
+		// Uzun süre giriş yapılmayan hesaplar zamanlanmış görev ile pasifleştirilir
+		From:           []models.UserStatus{models.StatusActive},
+		To:             models.StatusPassive,
+		Triggers:       []string{models.StatusTriggerSystem},
+		RequiresReason: true,
+	},
+	models.StatusEventBan: {
+		// Banlı bir kullanıcı yeni sebep ve süre ile tekrar banlanabilir
+		From:           []models.UserStatus{models.StatusActive, models.StatusPassive, models.StatusBanned},
+		To:             models.StatusBanned,
+		Triggers:       []string{models.StatusTriggerAdmin},
+		RequiresReason: true,
+	},
+	models.StatusEventUnban: {
+		From:     []models.UserStatus{models.StatusBanned},
+		To:       models.StatusActive,
+		Triggers: []string{models.StatusTriggerAdmin, models.StatusTriggerSystem},
+	},
+	models.StatusEventFreeze: {
+		From:           []models.UserStatus{models.StatusActive, models.StatusPassive},
+		To:             models.StatusFrozen,
+		Triggers:       []string{models.StatusTriggerSelf},
+		RequiresReason: true,
+	},
+	models.StatusEventDelete: {
+		From:     []models.UserStatus{models.StatusActive, models.StatusPassive, models.StatusBanned},
+		To:       models.StatusFrozen,
+		Triggers: []string{models.StatusTriggerSelf, models.StatusTriggerAdmin},
+	},
+	models.StatusEventReactivate: {
+		From:     []models.UserStatus{models.StatusFrozen},
+		To:       models.StatusActive,
+		Triggers: []string{models.StatusTriggerSelf},
+	},
+	models.StatusEventRestore: {
+		From:     []models.UserStatus{models.StatusFrozen},
+		To:       models.StatusActive,
+		Triggers: []string{models.StatusTriggerAdmin},
+	},
+}
+
+// statusTrigger tells whether a change on user is made by the user, an admin or the system
+func statusTrigger(user *models.User, actor *Actor) string {
+	switch {
+	case actor == nil:
+		return models.StatusTriggerSystem
+	case actor.ID == user.ID:
+		return models.StatusTriggerSelf
+	case actor.Role == models.RoleAdmin || actor.Role == models.RoleSuperAdmin:
+		return models.StatusTriggerAdmin
+	}
+	return ""
+}
+
+// checkStatusTransition verifies that event may be applied to user by actor.
+// Role based rules (who may act on whom) are checked separately.
+func checkStatusTransition(user *models.User, event models.StatusEvent, actor *Actor, reason string) error {
+	transition, ok := statusTransitions[event]
+	if !ok {
+		return ErrInvalidStatusTransition
+	}
+
+	if !containsStatus(transition.From, user.Status) {
+		return ErrInvalidStatusTransition
+	}
+
+	if !containsString(transition.Triggers, statusTrigger(user, actor)) {
+		return ErrForbidden
+	}
+
+	if transition.RequiresReason && reason == "" {
+		return ErrStatusReasonRequired
+	}
+
+	return nil
+}
+
+// transitionStatus applies event to user and records it in the status history. Users
+// cannot activate themselves after an admin made them passive.
+// It only changes user.Status in memory, the caller saves the user in the same transaction.
+func transitionStatus(tx *gorm.DB, user *models.User, event models.StatusEvent, actor *Actor, reason string) error {
+	if err := checkStatusTransition(user, event, actor, reason); err != nil {
+		return err
+	}
+
+	// Yönetici tarafından pasifleştirilen hesap kendini etkinleştiremez
+	if event == models.StatusEventActivate && statusTrigger(user, actor) == models.StatusTriggerSelf {
+		trigger, err := lastPassivationTrigger(tx, user.ID)
+		if err != nil {
+			return err
+		}
+		if trigger == models.StatusTriggerAdmin {
+			return ErrInvalidStatusTransition
+		}
+	}
+
+	history := models.UserStatusHistory{
+		UserID:      user.ID,
+		Event:       event,
+		FromStatus:  user.Status,
+		ToStatus:    statusTransitions[event].To,
+		TriggeredBy: statusTrigger(user, actor),
+		Reason:      reason,
+	}
+	if actor != nil {
+		history.ActorID = &actor.ID
+	}
+	if err := tx.Create(&history).Error; err != nil {
+		return err
+	}
+
+	user.Status = history.ToStatus
+	return nil
+}
+
+// statusEventFor maps a status requested through UpdateUserStatus to its event.
+// Banning, freezing and deleting have their own endpoints with required fields.
+func statusEventFor(status models.UserStatus) (models.StatusEvent, bool) {
+	switch status {
+	case models.StatusActive:
+		return models.StatusEventActivate, true
+	case models.StatusPassive:
+		return models.StatusEventDeactivate, true
+	}
+	return "", false
+}
+
+func containsStatus(list []models.UserStatus, status models.UserStatus) bool {
+	for _, s := range list {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, value string) bool {
+	for _, s := range list {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
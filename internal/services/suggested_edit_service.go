@@ -0,0 +1,355 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrSuggestedEditNotFound  = errors.New("suggested edit not found")
+	ErrSuggestedEditPending   = errors.New("post already has a pending suggested edit")
+	ErrSuggestedEditReviewed  = errors.New("suggested edit is already reviewed")
+	ErrSuggestedEditOutdated  = errors.New("post was edited after the suggestion")
+	ErrSuggestedEditUnchanged = errors.New("suggested edit does not change the post")
+	ErrCanEditDirectly        = errors.New("user can edit the post directly")
+)
+
+// SuggestedEditInput holds the proposed content of a suggested edit, nil fields are
+// kept. Title and Tags only apply to questions.
+type SuggestedEditInput struct {
+	Title   *string
+	Body    *string
+	Tags    *[]string
+	Summary string
+}
+
+// SuggestedEditFilter contains the filters of the suggested edit listing
+type SuggestedEditFilter struct {
+	Status   models.SuggestedEditStatus
+	AuthorID uint
+	Page     int
+	Limit    int
+}
+
+type SuggestedEditService struct {
+	db *gorm.DB
+}
+
+func NewSuggestedEditService(db *gorm.DB) *SuggestedEditService {
+	return &SuggestedEditService{db: db}
+}
+
+// Suggest proposes an edit to a post of another user. It is meant for users who cannot
+// edit the post directly; reviewers apply it as a new revision once they approve it.
+func (s *SuggestedEditService) Suggest(ctx context.Context, postType models.PostType, postID uint, input SuggestedEditInput, actor *Actor) (*models.SuggestedEdit, error) {
+	db := s.db.WithContext(ctx)
+	author, err := activeAuthor(db, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	postAuthor, err := postAuthorID(db, postType, postID)
+	if err != nil {
+		return nil, err
+	}
+	err = checkEditPermission(db, postAuthor, actor)
+	if err == nil {
+		return nil, ErrCanEditDirectly
+	}
+	var insufficient *InsufficientReputationError
+	if !errors.As(err, &insufficient) {
+		return nil, err
+	}
+
+	base, err := latestRevision(db, postType, postID)
+	if err != nil {
+		return nil, err
+	}
+	if base == nil {
+		return nil, ErrRevisionNotFound
+	}
+
+	edit := &models.SuggestedEdit{
+		PostType:     postType,
+		PostID:       postID,
+		BaseRevision: base.Revision,
+		Title:        base.Title,
+		Body:         base.Body,
+		Tags:         base.Tags,
+		Summary:      strings.TrimSpace(input.Summary),
+		AuthorID:     author.ID,
+		Author:       *author,
+		Status:       models.SuggestedEditPending,
+	}
+	if err := applySuggestedContent(db, edit, input); err != nil {
+		return nil, err
+	}
+	if edit.Title == base.Title && edit.Body == base.Body && edit.Tags == base.Tags {
+		return nil, ErrSuggestedEditUnchanged
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// Gönderi kilitlenir, aynı anda iki öneri beklemeye alınmasın
+		if _, err := lockPost(tx, postType, postID); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.SuggestedEdit{}).
+			Where("post_type = ? AND post_id = ? AND status = ?", postType, postID, models.SuggestedEditPending).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrSuggestedEditPending
+		}
+
+		return tx.Omit("Author", "Reviewer").Create(edit).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return edit, nil
+}
+
+// Get returns a suggested edit with its author and reviewer
+func (s *SuggestedEditService) Get(id uint) (*models.SuggestedEdit, error) {
+	var edit models.SuggestedEdit
+	if err := s.db.Preload("Author", withDeletedUsers).
+		Preload("Reviewer", withDeletedUsers).
+		First(&edit, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSuggestedEditNotFound
+		}
+		return nil, err
+	}
+	return &edit, nil
+}
+
+// Diff compares a suggested edit word by word with the revision it is based on
+func (s *SuggestedEditService) Diff(edit *models.SuggestedEdit) (*RevisionDiff, error) {
+	base, err := findRevision(s.db, edit.PostType, edit.PostID, edit.BaseRevision)
+	if err != nil {
+		return nil, err
+	}
+	return diffRevisions(base, edit.ProposedRevision()), nil
+}
+
+// List returns a page of suggested edits, oldest first. Reviewers see every suggestion;
+// other users can only list their own.
+func (s *SuggestedEditService) List(filter SuggestedEditFilter, actor *Actor) ([]models.SuggestedEdit, int64, error) {
+	if filter.AuthorID != actor.ID {
+		if err := checkReviewPermission(s.db, actor); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	query := s.db.Model(&models.SuggestedEdit{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.AuthorID != 0 {
+		query = query.Where("author_id = ?", filter.AuthorID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var edits []models.SuggestedEdit
+	if err := query.Preload("Author", withDeletedUsers).
+		Preload("Reviewer", withDeletedUsers).
+		Order("created_at, id").
+		Offset((filter.Page - 1) * filter.Limit).
+		Limit(filter.Limit).
+		Find(&edits).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return edits, total, nil
+}
+
+// Approve applies a suggested edit as a new revision credited to its author
+func (s *SuggestedEditService) Approve(ctx context.Context, id uint, actor *Actor) (*models.SuggestedEdit, error) {
+	return s.review(ctx, id, actor, func(tx *gorm.DB, edit *models.SuggestedEdit) (map[string]interface{}, error) {
+		revision, err := applySuggestedEdit(tx, edit)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"status":   models.SuggestedEditApproved,
+			"revision": revision,
+		}, nil
+	})
+}
+
+// Improve applies a suggested edit and then the changes of the reviewer on top of it,
+// as a second revision credited to the reviewer
+func (s *SuggestedEditService) Improve(ctx context.Context, id uint, input SuggestedEditInput, actor *Actor) (*models.SuggestedEdit, error) {
+	return s.review(ctx, id, actor, func(tx *gorm.DB, edit *models.SuggestedEdit) (map[string]interface{}, error) {
+		revision, err := applySuggestedEdit(tx, edit)
+		if err != nil {
+			return nil, err
+		}
+
+		meta := revisionMeta{EditorID: actor.ID, Summary: strings.TrimSpace(input.Summary)}
+		if edit.PostType == models.PostTypeAnswer {
+			if input.Body != nil {
+				if err := editAnswer(tx, edit.PostID, *input.Body, meta); err != nil {
+					return nil, err
+				}
+			}
+		} else if input.Title != nil || input.Body != nil || input.Tags != nil {
+			improvement := questionEdit{Title: input.Title, Body: input.Body, Tags: input.Tags}
+			if err := editQuestion(tx, edit.PostID, improvement, meta); err != nil {
+				return nil, err
+			}
+		}
+
+		return map[string]interface{}{
+			"status":   models.SuggestedEditImproved,
+			"revision": revision,
+		}, nil
+	})
+}
+
+// Reject declines a suggested edit with a reason shown to its author
+func (s *SuggestedEditService) Reject(ctx context.Context, id uint, reason string, actor *Actor) (*models.SuggestedEdit, error) {
+	return s.review(ctx, id, actor, func(tx *gorm.DB, edit *models.SuggestedEdit) (map[string]interface{}, error) {
+		return map[string]interface{}{
+			"status":        models.SuggestedEditRejected,
+			"reject_reason": strings.TrimSpace(reason),
+		}, nil
+	})
+}
+
+// review locks a pending suggested edit, lets decide apply the decision and stores the
+// returned changes together with the reviewer
+func (s *SuggestedEditService) review(ctx context.Context, id uint, actor *Actor, decide func(tx *gorm.DB, edit *models.SuggestedEdit) (map[string]interface{}, error)) (*models.SuggestedEdit, error) {
+	db := s.db.WithContext(ctx)
+	if err := checkReviewPermission(db, actor); err != nil {
+		return nil, err
+	}
+	if _, err := activeAuthor(db, actor.ID); err != nil {
+		return nil, err
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		edit, err := lockSuggestedEdit(tx, id)
+		if err != nil {
+			return err
+		}
+		if edit.Status != models.SuggestedEditPending {
+			return ErrSuggestedEditReviewed
+		}
+		// Kullanıcılar kendi önerilerini inceleyemez
+		if edit.AuthorID == actor.ID {
+			return ErrForbidden
+		}
+
+		changes, err := decide(tx, edit)
+		if err != nil {
+			return err
+		}
+		changes["reviewer_id"] = actor.ID
+		changes["reviewed_at"] = time.Now()
+
+		return tx.Model(edit).Updates(changes).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(id)
+}
+
+// applySuggestedEdit writes the content of a locked suggested edit to its post and
+// returns the revision it became. Posts edited after the suggestion are not overwritten.
+func applySuggestedEdit(tx *gorm.DB, edit *models.SuggestedEdit) (int, error) {
+	if _, err := lockPost(tx, edit.PostType, edit.PostID); err != nil {
+		return 0, err
+	}
+	latest, err := latestRevision(tx, edit.PostType, edit.PostID)
+	if err != nil {
+		return 0, err
+	}
+	if latest == nil || latest.Revision != edit.BaseRevision {
+		return 0, ErrSuggestedEditOutdated
+	}
+
+	meta := revisionMeta{EditorID: edit.AuthorID, Summary: edit.Summary}
+	if edit.PostType == models.PostTypeAnswer {
+		err = editAnswer(tx, edit.PostID, edit.Body, meta)
+	} else {
+		question := questionEdit{Title: &edit.Title, Body: &edit.Body}
+		// Etiketlerden önceki revizyonlara dayanan önerilerde etiket bulunmaz, mevcut etiketler korunur
+		if tags := edit.TagNames(); len(tags) > 0 {
+			question.Tags = &tags
+		}
+		err = editQuestion(tx, edit.PostID, question, meta)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if latest, err = latestRevision(tx, edit.PostType, edit.PostID); err != nil {
+		return 0, err
+	}
+	return latest.Revision, nil
+}
+
+// applySuggestedContent puts the proposed fields of input into edit. Tags are
+// normalized and mapped to their canonical names but not created until approval.
+func applySuggestedContent(tx *gorm.DB, edit *models.SuggestedEdit, input SuggestedEditInput) error {
+	if input.Body != nil {
+		edit.Body = *input.Body
+	}
+	if edit.PostType != models.PostTypeQuestion {
+		return nil
+	}
+
+	if input.Title != nil {
+		edit.Title = strings.TrimSpace(*input.Title)
+	}
+	if input.Tags != nil {
+		names, err := normalizeTagNames(*input.Tags)
+		if err != nil {
+			return err
+		}
+		if names, err = remapSynonyms(tx, names); err != nil {
+			return err
+		}
+		sort.Strings(names)
+		edit.Tags = strings.Join(names, " ")
+	}
+	return nil
+}
+
+// checkReviewPermission allows editors, admins and users with the edit_posts privilege
+// to review suggested edits
+func checkReviewPermission(tx *gorm.DB, actor *Actor) error {
+	if isEditorRole(actor.Role) {
+		return nil
+	}
+	return checkPrivilege(tx, actor, models.PrivilegeEditPosts)
+}
+
+func lockSuggestedEdit(tx *gorm.DB, id uint) (*models.SuggestedEdit, error) {
+	var edit models.SuggestedEdit
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&edit, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSuggestedEditNotFound
+		}
+		return nil, err
+	}
+	return &edit, nil
+}
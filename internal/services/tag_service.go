@@ -0,0 +1,343 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrTagNotFound    = errors.New("tag not found")
+	ErrInvalidTagName = errors.New("invalid tag name")
+	ErrTagCount       = errors.New("a question needs 1 to 5 tags")
+)
+
+const (
+	minQuestionTags  = 1
+	maxQuestionTags  = 5
+	maxTagNameLength = 35
+)
+
+// tagNamePattern allows names like "go", "c#", "node.js" or "sql-server"
+var tagNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9+#.\-]*$`)
+
+// tagSortColumns maps the sort modes of List to SQL expressions
+var tagSortColumns = map[string]string{
+	"popular": "tags.question_count DESC, tags.name ASC",
+	"name":    "tags.name ASC",
+	"newest":  "tags.created_at DESC, tags.id DESC",
+}
+
+// TagWikiUpdate holds the fields of a tag wiki edit, nil fields are kept
+type TagWikiUpdate struct {
+	Excerpt *string
+	Wiki    *string
+}
+
+type TagService struct {
+	db *gorm.DB
+}
+
+func NewTagService(db *gorm.DB) *TagService {
+	return &TagService{db: db}
+}
+
+// List returns a page of tags without their wiki. search matches anywhere in the name.
+func (s *TagService) List(search, sort string, page, limit int) ([]models.Tag, int64, error) {
+	order, ok := tagSortColumns[sort]
+	if !ok {
+		order = tagSortColumns["popular"]
+	}
+
+	query := s.db.Model(&models.Tag{})
+	if search = strings.ToLower(strings.TrimSpace(search)); search != "" {
+		query = query.Where("tags.name LIKE ?", "%"+escapeLike(search)+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var tags []models.Tag
+	if err := query.Omit("wiki").
+		Order(order).
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&tags).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return tags, total, nil
+}
+
+// Get returns a tag with its wiki. An approved synonym returns its target tag.
+func (s *TagService) Get(name string) (*models.Tag, error) {
+	return findCanonicalTag(s.db, name)
+}
+
+// UpdateWiki edits the excerpt and wiki of a tag. Only editors and admins may edit them.
+func (s *TagService) UpdateWiki(ctx context.Context, name string, update TagWikiUpdate, actor *Actor) (*models.Tag, error) {
+	if !isEditorRole(actor.Role) {
+		return nil, ErrForbidden
+	}
+	if _, err := activeAuthor(s.db.WithContext(ctx), actor.ID); err != nil {
+		return nil, err
+	}
+
+	tag, err := findCanonicalTag(s.db.WithContext(ctx), name)
+	if err != nil {
+		return nil, err
+	}
+	if update.Excerpt == nil && update.Wiki == nil {
+		return tag, nil
+	}
+
+	changes := map[string]interface{}{
+		"wiki_updated_by": actor.ID,
+	}
+	if update.Excerpt != nil {
+		changes["excerpt"] = strings.TrimSpace(*update.Excerpt)
+	}
+	if update.Wiki != nil {
+		changes["wiki"] = *update.Wiki
+	}
+
+	if err := s.db.WithContext(ctx).Model(tag).Updates(changes).Error; err != nil {
+		return nil, err
+	}
+
+	return findTag(s.db.WithContext(ctx), tag.Name)
+}
+
+// SetPreference makes a user follow or ignore a tag, replacing an earlier preference
+func (s *TagService) SetPreference(ctx context.Context, userID uint, name string, preference models.TagPreference) (*models.UserTagPreference, error) {
+	tag, err := findCanonicalTag(s.db.WithContext(ctx), name)
+	if err != nil {
+		return nil, err
+	}
+
+	pref := &models.UserTagPreference{
+		UserID:     userID,
+		TagID:      tag.ID,
+		Tag:        *tag,
+		Preference: preference,
+	}
+	if err := s.db.WithContext(ctx).Omit("Tag").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "tag_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"preference"}),
+	}).Create(pref).Error; err != nil {
+		return nil, err
+	}
+
+	return pref, nil
+}
+
+// ClearPreference removes the follow or ignore preference of a user for a tag
+func (s *TagService) ClearPreference(ctx context.Context, userID uint, name string) error {
+	tag, err := findCanonicalTag(s.db.WithContext(ctx), name)
+	if err != nil {
+		return err
+	}
+
+	return s.db.WithContext(ctx).
+		Where("user_id = ? AND tag_id = ?", userID, tag.ID).
+		Delete(&models.UserTagPreference{}).Error
+}
+
+// Preferences returns the followed and ignored tags of a user
+func (s *TagService) Preferences(userID uint) ([]models.UserTagPreference, error) {
+	var prefs []models.UserTagPreference
+	if err := s.db.Preload("Tag", omitTagWiki).
+		Where("user_id = ?", userID).
+		Order("preference, created_at").
+		Find(&prefs).Error; err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+func findTag(tx *gorm.DB, name string) (*models.Tag, error) {
+	var tag models.Tag
+	if err := tx.Where("name = ?", normalizeTagName(name)).First(&tag).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTagNotFound
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// findCanonicalTag finds a tag by name. Approved synonyms take precedence, so a tag
+// that is being merged into another one already resolves to its target.
+func findCanonicalTag(tx *gorm.DB, name string) (*models.Tag, error) {
+	var synonym models.TagSynonym
+	err := tx.Preload("Target").
+		Where("synonym = ? AND status = ?", normalizeTagName(name), models.TagSynonymApproved).
+		First(&synonym).Error
+	if err == nil {
+		return &synonym.Target, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	return findTag(tx, name)
+}
+
+// omitTagWiki preloads tags without their wiki
+func omitTagWiki(db *gorm.DB) *gorm.DB {
+	return db.Omit("wiki")
+}
+
+// isEditorRole reports whether role may curate tags
+func isEditorRole(role models.UserRole) bool {
+	return role == models.RoleEditor || isAdminRole(role)
+}
+
+// normalizeTagName lowercases a tag name and joins words with dashes
+func normalizeTagName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "-")
+}
+
+// normalizeTagNames validates the tags of a question and removes duplicates
+func normalizeTagNames(names []string) ([]string, error) {
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = normalizeTagName(name)
+		if !validTagName(name) {
+			return nil, ErrInvalidTagName
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+
+	if len(normalized) < minQuestionTags || len(normalized) > maxQuestionTags {
+		return nil, ErrTagCount
+	}
+	return normalized, nil
+}
+
+// validTagName reports whether a normalized name can be used as a tag
+func validTagName(name string) bool {
+	return len(name) <= maxTagNameLength && tagNamePattern.MatchString(name)
+}
+
+// resolveTags returns the tags with the given names and creates the missing ones.
+// Approved synonyms are replaced by their target tags.
+func resolveTags(tx *gorm.DB, names []string) ([]models.Tag, error) {
+	names, err := normalizeTagNames(names)
+	if err != nil {
+		return nil, err
+	}
+	if names, err = remapSynonyms(tx, names); err != nil {
+		return nil, err
+	}
+
+	missing := make([]models.Tag, 0, len(names))
+	for _, name := range names {
+		missing = append(missing, models.Tag{Name: name})
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&missing).Error; err != nil {
+		return nil, err
+	}
+
+	var tags []models.Tag
+	if err := tx.Omit("wiki").Where("name IN ?", names).Order("name").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// remapSynonyms replaces approved synonyms by the names of their target tags.
+// Two names may map to the same tag, so duplicates are removed again.
+func remapSynonyms(tx *gorm.DB, names []string) ([]string, error) {
+	var synonyms []models.TagSynonym
+	if err := tx.Preload("Target", func(db *gorm.DB) *gorm.DB { return db.Select("id", "name") }).
+		Where("synonym IN ? AND status = ?", names, models.TagSynonymApproved).
+		Find(&synonyms).Error; err != nil {
+		return nil, err
+	}
+	if len(synonyms) == 0 {
+		return names, nil
+	}
+
+	targets := make(map[string]string, len(synonyms))
+	for _, synonym := range synonyms {
+		targets[synonym.Synonym] = synonym.Target.Name
+	}
+
+	seen := make(map[string]bool, len(names))
+	remapped := make([]string, 0, len(names))
+	for _, name := range names {
+		if target, ok := targets[name]; ok {
+			name = target
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		remapped = append(remapped, name)
+	}
+	return remapped, nil
+}
+
+// setQuestionTags replaces the tags of a question and keeps the question counts of
+// the tags up to date
+func setQuestionTags(tx *gorm.DB, questionID uint, tags []models.Tag) error {
+	var current []uint
+	if err := tx.Table("question_tags").Where("question_id = ?", questionID).Pluck("tag_id", &current).Error; err != nil {
+		return err
+	}
+
+	wanted := make(map[uint]bool, len(tags))
+	for _, tag := range tags {
+		wanted[tag.ID] = true
+	}
+	existing := make(map[uint]bool, len(current))
+	var removed []uint
+	for _, id := range current {
+		existing[id] = true
+		if !wanted[id] {
+			removed = append(removed, id)
+		}
+	}
+	var added []uint
+	for _, tag := range tags {
+		if !existing[tag.ID] {
+			added = append(added, tag.ID)
+		}
+	}
+
+	if len(removed) > 0 {
+		if err := tx.Exec("DELETE FROM question_tags WHERE question_id = ? AND tag_id IN ?", questionID, removed).Error; err != nil {
+			return err
+		}
+		if err := addTagCounts(tx, removed, -1); err != nil {
+			return err
+		}
+	}
+	for _, id := range added {
+		if err := tx.Exec("INSERT INTO question_tags (question_id, tag_id) VALUES (?, ?)", questionID, id).Error; err != nil {
+			return err
+		}
+	}
+	if len(added) > 0 {
+		return addTagCounts(tx, added, 1)
+	}
+	return nil
+}
+
+// addTagCounts changes the question count of tags by delta
+func addTagCounts(tx *gorm.DB, tagIDs []uint, delta int) error {
+	return tx.Model(&models.Tag{}).Where("id IN ?", tagIDs).
+		UpdateColumn("question_count", gorm.Expr("question_count + ?", delta)).Error
+}
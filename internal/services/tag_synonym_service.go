@@ -0,0 +1,591 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrTagSynonymNotFound = errors.New("tag synonym not found")
+	ErrTagSynonymExists   = errors.New("synonym is already approved or proposed")
+	ErrTagSynonymSelf     = errors.New("a tag cannot be a synonym of itself")
+	ErrTagSynonymReviewed = errors.New("synonym proposal is already reviewed")
+	ErrTagIsSynonym       = errors.New("tag is a synonym of another tag")
+	ErrTagMergeNotFound   = errors.New("tag merge not found")
+	ErrTagMergeInProgress = errors.New("tag is part of a merge in progress")
+)
+
+const (
+	// tagMergeBatchSize is the number of questions retagged between progress updates
+	tagMergeBatchSize = 100
+
+	// staleTagMergeAfter is how long a running merge may go without progress before
+	// ProcessPendingMerges picks it up again, e.g. after a restart
+	staleTagMergeAfter = 10 * time.Minute
+)
+
+type TagSynonymService struct {
+	db *gorm.DB
+}
+
+func NewTagSynonymService(db *gorm.DB) *TagSynonymService {
+	return &TagSynonymService{db: db}
+}
+
+// Propose suggests synonym as another name of a tag. Editors and admins review the proposal.
+func (s *TagSynonymService) Propose(ctx context.Context, tagName, synonym string, actor *Actor) (*models.TagSynonym, error) {
+	db := s.db.WithContext(ctx)
+	if _, err := activeAuthor(db, actor.ID); err != nil {
+		return nil, err
+	}
+
+	synonym = normalizeTagName(synonym)
+	if !validTagName(synonym) {
+		return nil, ErrInvalidTagName
+	}
+
+	target, err := findTag(db, tagName)
+	if err != nil {
+		return nil, err
+	}
+	if target.Name == synonym {
+		return nil, ErrTagSynonymSelf
+	}
+	if err := checkNotSynonym(db, target.Name); err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := db.Model(&models.TagSynonym{}).
+		Where("synonym = ? AND (status = ? OR (status = ? AND target_tag_id = ?))",
+			synonym, models.TagSynonymApproved, models.TagSynonymPending, target.ID).
+		Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, ErrTagSynonymExists
+	}
+
+	proposal := &models.TagSynonym{
+		Synonym:     synonym,
+		TargetTagID: target.ID,
+		Target:      *target,
+		Status:      models.TagSynonymPending,
+		ProposedBy:  actor.ID,
+	}
+	if err := db.Omit("Target").Create(proposal).Error; err != nil {
+		return nil, err
+	}
+
+	return proposal, nil
+}
+
+// ListForTag returns the approved and pending synonyms of a tag
+func (s *TagSynonymService) ListForTag(tagName string) ([]models.TagSynonym, error) {
+	tag, err := findCanonicalTag(s.db, tagName)
+	if err != nil {
+		return nil, err
+	}
+
+	var synonyms []models.TagSynonym
+	if err := s.db.Preload("Target", omitTagWiki).
+		Where("target_tag_id = ? AND status IN ?", tag.ID,
+			[]models.TagSynonymStatus{models.TagSynonymApproved, models.TagSynonymPending}).
+		Order("status, synonym").
+		Find(&synonyms).Error; err != nil {
+		return nil, err
+	}
+	return synonyms, nil
+}
+
+// List returns a page of synonym proposals with the given status, oldest first.
+// It is the review queue of editors and admins.
+func (s *TagSynonymService) List(status models.TagSynonymStatus, page, limit int, actor *Actor) ([]models.TagSynonym, int64, error) {
+	if !isEditorRole(actor.Role) {
+		return nil, 0, ErrForbidden
+	}
+
+	query := s.db.Model(&models.TagSynonym{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var synonyms []models.TagSynonym
+	if err := query.Preload("Target", omitTagWiki).
+		Order("created_at, id").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&synonyms).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return synonyms, total, nil
+}
+
+// Approve accepts a synonym proposal. Questions tagged with the synonym get the target
+// tag from then on; questions that already carry a tag with that name keep it until the
+// tags are merged with StartMerge.
+func (s *TagSynonymService) Approve(ctx context.Context, id uint, actor *Actor) (*models.TagSynonym, error) {
+	if !isEditorRole(actor.Role) {
+		return nil, ErrForbidden
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		synonym, err := lockTagSynonym(tx, id)
+		if err != nil {
+			return err
+		}
+		if synonym.Status != models.TagSynonymPending {
+			return ErrTagSynonymReviewed
+		}
+		// Öneriden sonra hedef etiketin kendisi eş anlamlı olmuş olabilir
+		if err := checkNotSynonym(tx, synonym.Target.Name); err != nil {
+			return err
+		}
+		if err := checkNotSynonym(tx, synonym.Synonym); err != nil {
+			return ErrTagSynonymExists
+		}
+
+		if err := approveSynonym(tx, synonym, actor); err != nil {
+			return err
+		}
+
+		return recordAuditEvent(tx, actor, auditEntry{
+			Action: models.AuditActionTagSynonymApproved,
+			Details: map[string]interface{}{
+				"synonym_id": synonym.ID,
+				"synonym":    synonym.Synonym,
+				"tag":        synonym.Target.Name,
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.get(id)
+}
+
+// Reject declines a synonym proposal with a reason
+func (s *TagSynonymService) Reject(ctx context.Context, id uint, reason string, actor *Actor) (*models.TagSynonym, error) {
+	if !isEditorRole(actor.Role) {
+		return nil, ErrForbidden
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		synonym, err := lockTagSynonym(tx, id)
+		if err != nil {
+			return err
+		}
+		if synonym.Status != models.TagSynonymPending {
+			return ErrTagSynonymReviewed
+		}
+
+		if err := tx.Model(synonym).Updates(map[string]interface{}{
+			"status":        models.TagSynonymRejected,
+			"reviewed_by":   actor.ID,
+			"reviewed_at":   time.Now(),
+			"reject_reason": reason,
+		}).Error; err != nil {
+			return err
+		}
+
+		return recordAuditEvent(tx, actor, auditEntry{
+			Action: models.AuditActionTagSynonymRejected,
+			Details: map[string]interface{}{
+				"synonym_id": synonym.ID,
+				"synonym":    synonym.Synonym,
+				"tag":        synonym.Target.Name,
+				"reason":     reason,
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.get(id)
+}
+
+// StartMerge merges the source tag into the target tag. The source name becomes an
+// approved synonym right away, the questions are retagged in the background and the
+// source tag is deleted at the end.
+func (s *TagSynonymService) StartMerge(ctx context.Context, sourceName, targetName string, actor *Actor) (*models.TagMerge, error) {
+	db := s.db.WithContext(ctx)
+	source, err := findTag(db, sourceName)
+	if err != nil {
+		return nil, err
+	}
+	target, err := findTag(db, targetName)
+	if err != nil {
+		return nil, err
+	}
+	if source.ID == target.ID {
+		return nil, ErrTagSynonymSelf
+	}
+
+	merge := &models.TagMerge{
+		SourceTagID: &source.ID,
+		SourceName:  source.Name,
+		TargetTagID: &target.ID,
+		TargetName:  target.Name,
+		ActorID:     actor.ID,
+		Status:      models.TagMergePending,
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// Etiketler kilitlenir, aynı etiketler için iki birleştirme başlatılamaz
+		var locked []models.Tag
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", []uint{source.ID, target.ID}).
+			Order("id").
+			Find(&locked).Error; err != nil {
+			return err
+		}
+		if len(locked) != 2 {
+			return ErrTagNotFound
+		}
+		for _, tag := range locked {
+			if tag.ID == source.ID {
+				merge.Total = tag.QuestionCount
+			}
+		}
+
+		var active int64
+		if err := tx.Model(&models.TagMerge{}).
+			Where("status IN ? AND (source_tag_id IN ? OR target_tag_id IN ?)",
+				[]models.TagMergeStatus{models.TagMergePending, models.TagMergeRunning},
+				[]uint{source.ID, target.ID}, []uint{source.ID, target.ID}).
+			Count(&active).Error; err != nil {
+			return err
+		}
+		if active > 0 {
+			return ErrTagMergeInProgress
+		}
+		if err := checkNotSynonym(tx, target.Name); err != nil {
+			return err
+		}
+
+		// Kaynak etiket daha önce hedefin eş anlamlısı olarak onaylanmış olabilir
+		var existing models.TagSynonym
+		err := tx.Where("synonym = ? AND status = ?", source.Name, models.TagSynonymApproved).First(&existing).Error
+		switch {
+		case err == nil && existing.TargetTagID != target.ID:
+			return ErrTagIsSynonym
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			synonym := &models.TagSynonym{
+				Synonym:     source.Name,
+				TargetTagID: target.ID,
+				Target:      *target,
+				Status:      models.TagSynonymPending,
+				ProposedBy:  actor.ID,
+			}
+			if err := tx.Omit("Target").Create(synonym).Error; err != nil {
+				return err
+			}
+			if err := approveSynonym(tx, synonym, actor); err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		}
+
+		if err := tx.Model(&models.TagSynonym{}).
+			Where("status = ? AND target_tag_id = ?", models.TagSynonymApproved, source.ID).
+			Update("target_tag_id", target.ID).Error; err != nil {
+			return err
+		}
+
+		// Kaynağa yönelik bekleyen öneriler artık onaylanamaz
+		if err := tx.Model(&models.TagSynonym{}).
+			Where("status = ? AND (target_tag_id = ? OR synonym = ?)", models.TagSynonymPending, source.ID, source.Name).
+			Updates(map[string]interface{}{
+				"status":        models.TagSynonymRejected,
+				"reviewed_by":   actor.ID,
+				"reviewed_at":   time.Now(),
+				"reject_reason": fmt.Sprintf("Tag %s was merged into %s", source.Name, target.Name),
+			}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(merge).Error; err != nil {
+			return err
+		}
+
+		return recordAuditEvent(tx, actor, auditEntry{
+			Action: models.AuditActionTagMergeStarted,
+			Details: map[string]interface{}{
+				"merge_id":  merge.ID,
+				"source":    source.Name,
+				"target":    target.Name,
+				"questions": merge.Total,
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go s.runMerge(context.Background(), merge.ID)
+	return merge, nil
+}
+
+// GetMerge returns a tag merge with its progress
+func (s *TagSynonymService) GetMerge(id uint) (*models.TagMerge, error) {
+	var merge models.TagMerge
+	if err := s.db.First(&merge, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTagMergeNotFound
+		}
+		return nil, err
+	}
+	return &merge, nil
+}
+
+// ProcessPendingMerges runs merges that have not been started and resumes running
+// merges that stopped making progress, e.g. because the server was restarted
+func (s *TagSynonymService) ProcessPendingMerges(ctx context.Context) error {
+	var ids []uint
+	if err := s.db.WithContext(ctx).Model(&models.TagMerge{}).
+		Where("status = ? OR (status = ? AND updated_at < ?)",
+			models.TagMergePending, models.TagMergeRunning, time.Now().Add(-staleTagMergeAfter)).
+		Order("id").
+		Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		s.runMerge(ctx, id)
+	}
+	return nil
+}
+
+// runMerge claims a merge and retags its questions. Every question is moved in its
+// own transaction, so an interrupted merge can be resumed without counting twice.
+func (s *TagSynonymService) runMerge(ctx context.Context, id uint) {
+	claim := s.db.WithContext(ctx).Model(&models.TagMerge{}).
+		Where("id = ? AND (status = ? OR (status = ? AND updated_at < ?))",
+			id, models.TagMergePending, models.TagMergeRunning, time.Now().Add(-staleTagMergeAfter)).
+		Update("status", models.TagMergeRunning)
+	if claim.Error != nil {
+		log.Printf("Failed to start tag merge %d: %v", id, claim.Error)
+		return
+	}
+	if claim.RowsAffected == 0 {
+		return
+	}
+
+	merge, err := s.GetMerge(id)
+	if err != nil {
+		log.Printf("Failed to load tag merge %d: %v", id, err)
+		return
+	}
+
+	err = s.retagQuestions(ctx, merge)
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":       models.TagMergeCompleted,
+		"processed":    merge.Processed,
+		"completed_at": now,
+	}
+	if err != nil {
+		log.Printf("Tag merge %d failed: %v", id, err)
+		updates["status"] = models.TagMergeFailed
+		updates["error"] = err.Error()
+	}
+	if err := s.db.Model(merge).Updates(updates).Error; err != nil {
+		log.Printf("Failed to save tag merge %d: %v", id, err)
+	}
+}
+
+func (s *TagSynonymService) retagQuestions(ctx context.Context, merge *models.TagMerge) error {
+	if merge.TargetTagID == nil {
+		return ErrTagNotFound
+	}
+	// Kaynak etiket önceki bir çalıştırmada silinmiş olabilir
+	if merge.SourceTagID == nil {
+		return nil
+	}
+	sourceID, targetID := *merge.SourceTagID, *merge.TargetTagID
+
+	for {
+		var questionIDs []uint
+		if err := s.db.WithContext(ctx).Table("question_tags").
+			Where("tag_id = ?", sourceID).
+			Order("question_id").
+			Limit(tagMergeBatchSize).
+			Pluck("question_id", &questionIDs).Error; err != nil {
+			return err
+		}
+
+		if len(questionIDs) == 0 {
+			done, err := s.finishMerge(ctx, sourceID, targetID)
+			if err != nil || done {
+				return err
+			}
+			continue
+		}
+
+		for _, questionID := range questionIDs {
+			if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				return retagQuestion(tx, questionID, sourceID, targetID)
+			}); err != nil {
+				return err
+			}
+			merge.Processed++
+		}
+
+		// İlerleme kaydı aynı zamanda birleştirmenin hâlâ çalıştığını gösterir
+		if err := s.db.Model(merge).Update("processed", merge.Processed).Error; err != nil {
+			return err
+		}
+	}
+}
+
+// finishMerge moves the tag preferences to the target and deletes the source tag.
+// It reports false when a question got the source tag in the meantime.
+func (s *TagSynonymService) finishMerge(ctx context.Context, sourceID, targetID uint) (bool, error) {
+	done := false
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Kilit, kaynağa eşzamanlı eklenen soru etiketlerini bekletir
+		var source models.Tag
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&source, sourceID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				done = true
+				return nil
+			}
+			return err
+		}
+
+		var remaining int64
+		if err := tx.Table("question_tags").Where("tag_id = ?", sourceID).Count(&remaining).Error; err != nil {
+			return err
+		}
+		if remaining > 0 {
+			return nil
+		}
+
+		if err := tx.Exec(`INSERT INTO user_tag_preferences (user_id, tag_id, preference, created_at)
+			SELECT user_id, ?, preference, created_at FROM user_tag_preferences WHERE tag_id = ?
+			ON CONFLICT DO NOTHING`, targetID, sourceID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("tag_id = ?", sourceID).Delete(&models.UserTagPreference{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.Tag{}, sourceID).Error; err != nil {
+			return err
+		}
+
+		done = true
+		return nil
+	})
+	return done, err
+}
+
+// retagQuestion replaces the source tag of a question by the target tag. A question
+// that already has the target tag only loses the source tag.
+func retagQuestion(tx *gorm.DB, questionID, sourceID, targetID uint) error {
+	// Soru kilitlenir, eşzamanlı düzenlemeler etiketleri değiştirmesin
+	var question models.Question
+	if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&question, questionID).Error; err != nil {
+		return err
+	}
+
+	var tagIDs []uint
+	if err := tx.Table("question_tags").Where("question_id = ?", questionID).Pluck("tag_id", &tagIDs).Error; err != nil {
+		return err
+	}
+
+	hasSource, hasTarget := false, false
+	for _, id := range tagIDs {
+		hasSource = hasSource || id == sourceID
+		hasTarget = hasTarget || id == targetID
+	}
+	if !hasSource {
+		return nil
+	}
+
+	if hasTarget {
+		if err := tx.Exec("DELETE FROM question_tags WHERE question_id = ? AND tag_id = ?", questionID, sourceID).Error; err != nil {
+			return err
+		}
+	} else {
+		if err := tx.Exec("UPDATE question_tags SET tag_id = ? WHERE question_id = ? AND tag_id = ?", targetID, questionID, sourceID).Error; err != nil {
+			return err
+		}
+		if err := addTagCounts(tx, []uint{targetID}, 1); err != nil {
+			return err
+		}
+	}
+	return addTagCounts(tx, []uint{sourceID}, -1)
+}
+
+// approveSynonym marks a synonym as approved. Synonyms of the tag named like the new
+// synonym are moved to its target, so synonyms never point to another synonym.
+func approveSynonym(tx *gorm.DB, synonym *models.TagSynonym, actor *Actor) error {
+	now := time.Now()
+	if err := tx.Model(synonym).Updates(map[string]interface{}{
+		"status":      models.TagSynonymApproved,
+		"reviewed_by": actor.ID,
+		"reviewed_at": now,
+	}).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&models.TagSynonym{}).
+		Where("status = ? AND target_tag_id IN (SELECT id FROM tags WHERE name = ?)", models.TagSynonymApproved, synonym.Synonym).
+		Update("target_tag_id", synonym.TargetTagID).Error
+}
+
+// checkNotSynonym returns ErrTagIsSynonym when name is an approved synonym
+func checkNotSynonym(tx *gorm.DB, name string) error {
+	var count int64
+	if err := tx.Model(&models.TagSynonym{}).
+		Where("synonym = ? AND status = ?", name, models.TagSynonymApproved).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrTagIsSynonym
+	}
+	return nil
+}
+
+func lockTagSynonym(tx *gorm.DB, id uint) (*models.TagSynonym, error) {
+	var synonym models.TagSynonym
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&synonym, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTagSynonymNotFound
+		}
+		return nil, err
+	}
+	if err := tx.Omit("wiki").First(&synonym.Target, synonym.TargetTagID).Error; err != nil {
+		return nil, err
+	}
+	return &synonym, nil
+}
+
+func (s *TagSynonymService) get(id uint) (*models.TagSynonym, error) {
+	var synonym models.TagSynonym
+	if err := s.db.Preload("Target", omitTagWiki).First(&synonym, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTagSynonymNotFound
+		}
+		return nil, err
+	}
+	return &synonym, nil
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrSelfVote = errors.New("users cannot vote on their own posts")
+
+var errUnknownPostType = errors.New("unknown post type")
+
+// postRef is the part of a question or answer needed to vote on it
+type postRef struct {
+	ID       uint
+	AuthorID uint
+	Score    int
+}
+
+// VoteResult is the state of a post after a vote
+type VoteResult struct {
+	Score    int `json:"score"`
+	UserVote int `json:"user_vote"`
+}
+
+type VoteService struct {
+	db *gorm.DB
+}
+
+func NewVoteService(db *gorm.DB) *VoteService {
+	return &VoteService{db: db}
+}
+
+// Vote casts or changes the vote of actor on a post. value is models.VoteUp or
+// models.VoteDown; voting the same way twice leaves the vote unchanged.
+func (s *VoteService) Vote(ctx context.Context, postType models.PostType, postID uint, value int, actor *Actor) (*VoteResult, error) {
+	if _, err := activeAuthor(s.db.WithContext(ctx), actor.ID); err != nil {
+		return nil, err
+	}
+
+	result := &VoteResult{UserVote: value}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Aynı gönderiye eşzamanlı oylar sırayla işlensin
+		post, err := lockPost(tx, postType, postID)
+		if err != nil {
+			return err
+		}
+		if post.AuthorID == actor.ID {
+			return ErrSelfVote
+		}
+
+		vote, err := findVote(tx, actor.ID, postType, postID)
+		if err != nil {
+			return err
+		}
+
+		previous := 0
+		if vote == nil {
+			vote = &models.Vote{UserID: actor.ID, PostType: postType, PostID: postID, Value: value}
+			if err := tx.Create(vote).Error; err != nil {
+				return err
+			}
+		} else if vote.Value != value {
+			previous = vote.Value
+			// Önceki oyun itibar etkisi geri alınır
+			if err := reverseVoteReputation(tx, vote.ID); err != nil {
+				return err
+			}
+			if err := tx.Model(vote).Update("value", value).Error; err != nil {
+				return err
+			}
+		} else {
+			result.Score = post.Score
+			return nil
+		}
+
+		if err := recordVoteReputation(tx, vote, post.AuthorID); err != nil {
+			return err
+		}
+
+		result.Score, err = addScore(tx, postType, post, value-previous)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Retract removes the vote of actor on a post. Retracting a missing vote is not an error.
+func (s *VoteService) Retract(ctx context.Context, postType models.PostType, postID uint, actor *Actor) (*VoteResult, error) {
+	result := &VoteResult{}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		post, err := lockPost(tx, postType, postID)
+		if err != nil {
+			return err
+		}
+
+		vote, err := findVote(tx, actor.ID, postType, postID)
+		if err != nil {
+			return err
+		}
+		if vote == nil {
+			result.Score = post.Score
+			return nil
+		}
+
+		if err := reverseVoteReputation(tx, vote.ID); err != nil {
+			return err
+		}
+		if err := tx.Delete(vote).Error; err != nil {
+			return err
+		}
+
+		result.Score, err = addScore(tx, postType, post, -vote.Value)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UserVotes returns the votes of a user on the given posts by post ID. Posts the
+// user has not voted on are missing from the map.
+func (s *VoteService) UserVotes(userID uint, postType models.PostType, postIDs []uint) (map[uint]int, error) {
+	votes := make(map[uint]int)
+	if userID == 0 || len(postIDs) == 0 {
+		return votes, nil
+	}
+
+	var rows []models.Vote
+	if err := s.db.Where("user_id = ? AND post_type = ? AND post_id IN ?", userID, postType, postIDs).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, vote := range rows {
+		votes[vote.PostID] = vote.Value
+	}
+	return votes, nil
+}
+
+// lockPost loads a question or an answer and locks its row until the transaction ends
+func lockPost(tx *gorm.DB, postType models.PostType, postID uint) (*postRef, error) {
+	var post postRef
+	var err error
+	switch postType {
+	case models.PostTypeQuestion:
+		err = tx.Model(&models.Question{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "author_id", "score").Where("id = ?", postID).Take(&post).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrQuestionNotFound
+		}
+	case models.PostTypeAnswer:
+		err = tx.Model(&models.Answer{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "author_id", "score").Where("id = ?", postID).Take(&post).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAnswerNotFound
+		}
+	default:
+		return nil, errUnknownPostType
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+func findVote(tx *gorm.DB, userID uint, postType models.PostType, postID uint) (*models.Vote, error) {
+	var vote models.Vote
+	if err := tx.Where("user_id = ? AND post_type = ? AND post_id = ?", userID, postType, postID).
+		First(&vote).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &vote, nil
+}
+
+// addScore changes the cached score of a locked post and returns the new score.
+// Votes are not edits, so updated_at and with it the "active" sort is left alone.
+func addScore(tx *gorm.DB, postType models.PostType, post *postRef, delta int) (int, error) {
+	if delta == 0 {
+		return post.Score, nil
+	}
+
+	model := interface{}(&models.Question{})
+	if postType == models.PostTypeAnswer {
+		model = &models.Answer{}
+	}
+	if err := tx.Model(model).Where("id = ?", post.ID).
+		UpdateColumn("score", gorm.Expr("score + ?", delta)).Error; err != nil {
+		return 0, err
+	}
+	return post.Score + delta, nil
+}
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anilsoylu/answer-backend/internal/models"
+	"github.com/anilsoylu/answer-backend/pkg/mailer"
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrUnknownWarningReason = errors.New("unknown warning reason")
+	ErrWarningNoteRequired  = errors.New("a note is required for this warning reason")
+	ErrWarningNotFound      = errors.New("warning not found")
+)
+
+const (
+	// defaultWarningPointsTTL is used when WARNING_POINTS_TTL is not set
+	defaultWarningPointsTTL = 90 * 24 * time.Hour
+	// defaultWarningEscalationRules is used when WARNING_ESCALATION_RULES is not set
+	defaultWarningEscalationRules = "3:1_day,6:1_week,10:1_month,15:permanent"
+)
+
+// warningReasons is the catalog of canned reasons moderators can pick from
+var warningReasons = []models.WarningReason{
+	{Code: models.WarningReasonSpam, Description: "Spam or unsolicited promotion", Points: 2},
+	{Code: models.WarningReasonHarassment, Description: "Harassment, insults or personal attacks", Points: 3},
+	{Code: models.WarningReasonOffTopic, Description: "Repeated off-topic or low quality posts", Points: 1},
+	{Code: models.WarningReasonPlagiarism, Description: "Posting content without attribution", Points: 2},
+	{Code: models.WarningReasonInappropriate, Description: "Offensive or inappropriate content", Points: 3},
+	{Code: models.WarningReasonVoteFraud, Description: "Vote manipulation or sock puppet accounts", Points: 4},
+	{Code: models.WarningReasonOther, Description: "Other, explained in the note", Points: 1, NoteRequired: true},
+}
+
+// banDurations lists the durations escalation rules may use, same as BanUserRequest
+var banDurations = map[string]bool{
+	"1_day":     true,
+	"1_week":    true,
+	"1_month":   true,
+	"permanent": true,
+}
+
+// escalationRule bans a user for BanDuration once the active warning points reach Points
+type escalationRule struct {
+	Points      int
+	BanDuration string
+}
+
+type WarningService struct {
+	db *gorm.DB
+}
+
+func NewWarningService(db *gorm.DB) *WarningService {
+	return &WarningService{db: db}
+}
+
+// Reasons returns the canned warning reasons
+func (s *WarningService) Reasons() []models.WarningReason {
+	return warningReasons
+}
+
+// Issue warns a user. When the user's active points cross an escalation threshold,
+// the user is banned in the same transaction. The returned int is the new point total.
+func (s *WarningService) Issue(ctx context.Context, userID uint, reason, note string, actor *Actor) (*models.UserWarning, int, error) {
+	canned, ok := findWarningReason(reason)
+	if !ok {
+		return nil, 0, ErrUnknownWarningReason
+	}
+	note = strings.TrimSpace(note)
+	if canned.NoteRequired && note == "" {
+		return nil, 0, ErrWarningNoteRequired
+	}
+	if userID == actor.ID {
+		return nil, 0, ErrForbidden
+	}
+
+	now := time.Now()
+	warning := &models.UserWarning{
+		UserID:    userID,
+		IssuedBy:  actor.ID,
+		Reason:    canned.Code,
+		Note:      note,
+		Points:    canned.Points,
+		ExpiresAt: now.Add(utils.GetEnvDuration("WARNING_POINTS_TTL", defaultWarningPointsTTL)),
+	}
+
+	var user models.User
+	var total int
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Aynı kullanıcıya eşzamanlı verilen uyarılar eşiği iki kez aşmasın
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, userID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserNotFound
+			}
+			return err
+		}
+
+		// Uyarı yetkisi ban yetkisiyle aynıdır
+		if err := checkBan(&user, actor.Role); err != nil {
+			return err
+		}
+
+		previous, err := activeWarningPoints(tx, userID, now)
+		if err != nil {
+			return err
+		}
+		total = previous + warning.Points
+
+		rule := crossedEscalationRule(previous, total)
+		if rule != nil && banExtends(&user, rule.BanDuration, now) {
+			warning.EscalatedBan = rule.BanDuration
+		}
+
+		if err := tx.Create(warning).Error; err != nil {
+			return err
+		}
+
+		if err := recordAuditEvent(tx, actor, auditEntry{
+			Action:       models.AuditActionUserWarned,
+			TargetUserID: userID,
+			Details: map[string]interface{}{
+				// Uyarı gerekçesi kişisel veri olarak silinir, denetim kaydına yazılmaz
+				"warning_id":    warning.ID,
+				"points":        warning.Points,
+				"total_points":  total,
+				"escalated_ban": warning.EscalatedBan,
+			},
+		}); err != nil {
+			return err
+		}
+
+		if warning.EscalatedBan == "" {
+			return nil
+		}
+		return applyBan(tx, &user, fmt.Sprintf("Automatic ban after reaching %d warning points", total), warning.EscalatedBan, actor, map[string]interface{}{
+			"warning_id":   warning.ID,
+			"total_points": total,
+		})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	body := fmt.Sprintf("Hello %s,\n\nA moderator has issued you a warning: %s.\n\nPlease review it and acknowledge it in your account at %s/account/warnings. Until then you cannot update your profile or post content.",
+		user.Username, canned.Description, os.Getenv("APP_URL"))
+	if err := mailer.Send(user.Email, "You have received a warning", body); err != nil {
+		log.Printf("Failed to send warning %d to user %d: %v", warning.ID, user.ID, err)
+	}
+
+	return warning, total, nil
+}
+
+// ListForUser returns all warnings of a user, newest first, and the active point total
+func (s *WarningService) ListForUser(userID uint) ([]models.UserWarning, int, error) {
+	var count int64
+	if err := s.db.Unscoped().Model(&models.User{}).Where("id = ?", userID).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	if count == 0 {
+		return nil, 0, ErrUserNotFound
+	}
+
+	var warnings []models.UserWarning
+	if err := s.db.Where("user_id = ?", userID).Order("created_at DESC, id DESC").Find(&warnings).Error; err != nil {
+		return nil, 0, err
+	}
+
+	points, err := activeWarningPoints(s.db, userID, time.Now())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return warnings, points, nil
+}
+
+// Acknowledge marks a warning of the user as read. Acknowledging twice is not an error.
+func (s *WarningService) Acknowledge(ctx context.Context, userID, warningID uint) (*models.UserWarning, error) {
+	var warning models.UserWarning
+	if err := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", warningID, userID).First(&warning).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWarningNotFound
+		}
+		return nil, err
+	}
+
+	if warning.AcknowledgedAt != nil {
+		return &warning, nil
+	}
+
+	now := time.Now()
+	if err := s.db.WithContext(ctx).Model(&models.UserWarning{}).
+		Where("id = ? AND acknowledged_at IS NULL", warning.ID).
+		Update("acknowledged_at", now).Error; err != nil {
+		return nil, err
+	}
+	warning.AcknowledgedAt = &now
+
+	return &warning, nil
+}
+
+// HasUnacknowledgedWarnings reports whether the user still has to acknowledge a warning.
+// middleware.RequireAcknowledgedWarnings calls it on routes that create or change content.
+func (s *WarningService) HasUnacknowledgedWarnings(userID uint) bool {
+	var count int64
+	if err := s.db.Model(&models.UserWarning{}).
+		Where("user_id = ? AND acknowledged_at IS NULL", userID).
+		Count(&count).Error; err != nil {
+		log.Printf("Failed to check warnings of user %d: %v", userID, err)
+		return false
+	}
+	return count > 0
+}
+
+func findWarningReason(code string) (models.WarningReason, bool) {
+	for _, reason := range warningReasons {
+		if reason.Code == code {
+			return reason, true
+		}
+	}
+	return models.WarningReason{}, false
+}
+
+// activeWarningPoints sums the points of warnings that have not decayed yet
+func activeWarningPoints(tx *gorm.DB, userID uint, now time.Time) (int, error) {
+	var points int
+	if err := tx.Model(&models.UserWarning{}).
+		Where("user_id = ? AND expires_at > ?", userID, now).
+		Select("COALESCE(SUM(points), 0)").
+		Scan(&points).Error; err != nil {
+		return 0, err
+	}
+	return points, nil
+}
+
+// crossedEscalationRule returns the strictest rule whose threshold lies in (previous, total]
+func crossedEscalationRule(previous, total int) *escalationRule {
+	var crossed *escalationRule
+	for _, rule := range escalationRules() {
+		if previous < rule.Points && rule.Points <= total {
+			r := rule
+			crossed = &r
+		}
+	}
+	return crossed
+}
+
+// escalationRules parses WARNING_ESCALATION_RULES ("points:duration,...") sorted by points.
+// Invalid entries are logged and skipped.
+func escalationRules() []escalationRule {
+	value := os.Getenv("WARNING_ESCALATION_RULES")
+	if value == "" {
+		value = defaultWarningEscalationRules
+	}
+	if value == "none" {
+		return nil
+	}
+
+	var rules []escalationRule
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 2)
+		if len(parts) != 2 {
+			log.Printf("Invalid warning escalation rule %q", entry)
+			continue
+		}
+		points, err := strconv.Atoi(parts[0])
+		if err != nil || points <= 0 || !banDurations[parts[1]] {
+			log.Printf("Invalid warning escalation rule %q", entry)
+			continue
+		}
+		rules = append(rules, escalationRule{Points: points, BanDuration: parts[1]})
+	}
+
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Points < rules[j].Points })
+	return rules
+}
+
+// banExtends reports whether banning user for banDuration now would not shorten a running ban
+func banExtends(user *models.User, banDuration string, now time.Time) bool {
+	if user.Status != models.StatusBanned {
+		return true
+	}
+	if user.BanEndDate == nil {
+		return false
+	}
+	end := banEndDate(banDuration, now)
+	return end == nil || end.After(*user.BanEndDate)
+}
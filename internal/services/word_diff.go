@@ -0,0 +1,150 @@
+package services
+
+import "regexp"
+
+// Diff operations of a DiffSegment
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
+
+// maxDiffEdits bounds the work of wordDiff. Texts that need more edits are shown as
+// replaced entirely after their common beginning and end.
+const maxDiffEdits = 1000
+
+// diffTokenPattern splits text into words and the whitespace between them, so that
+// joining the segments of a side gives back the original text
+var diffTokenPattern = regexp.MustCompile(`\s+|\S+`)
+
+// DiffSegment is a run of text that is kept, inserted or deleted between two revisions
+type DiffSegment struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// wordDiff returns the word-level difference between two texts
+func wordDiff(from, to string) []DiffSegment {
+	a := diffTokenPattern.FindAllString(from, -1)
+	b := diffTokenPattern.FindAllString(to, -1)
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	segments := []DiffSegment{}
+	add := func(op, text string) {
+		if last := len(segments) - 1; last >= 0 && segments[last].Op == op {
+			segments[last].Text += text
+			return
+		}
+		segments = append(segments, DiffSegment{Op: op, Text: text})
+	}
+
+	for _, token := range a[:prefix] {
+		add(DiffEqual, token)
+	}
+	for _, edit := range diffTokens(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		add(edit.Op, edit.Text)
+	}
+	for _, token := range a[len(a)-suffix:] {
+		add(DiffEqual, token)
+	}
+	return segments
+}
+
+// diffTokens finds a shortest edit script between two token lists with Myers' algorithm
+func diffTokens(a, b []string) []DiffSegment {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+
+	limit := n + m
+	if limit > maxDiffEdits {
+		limit = maxDiffEdits
+	}
+
+	// v[k+offset] is the furthest x reached on diagonal k. Before every step the
+	// diagonals -d-1..d+1 are kept for backtracking.
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+
+	for d := 0; d <= limit; d++ {
+		snapshot := make([]int, 2*d+3)
+		copy(snapshot, v[offset-d-1:offset+d+2])
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrackDiff(a, b, trace)
+			}
+		}
+	}
+
+	// Çok farklı metinler tamamen değişmiş gösterilir
+	edits := make([]DiffSegment, 0, n+m)
+	for _, token := range a {
+		edits = append(edits, DiffSegment{Op: DiffDelete, Text: token})
+	}
+	for _, token := range b {
+		edits = append(edits, DiffSegment{Op: DiffInsert, Text: token})
+	}
+	return edits
+}
+
+func backtrackDiff(a, b []string, trace [][]int) []DiffSegment {
+	var edits []DiffSegment
+	x, y := len(a), len(b)
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		at := func(k int) int { return v[k+d+1] }
+
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			edits = append(edits, DiffSegment{Op: DiffEqual, Text: a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, DiffSegment{Op: DiffInsert, Text: b[y-1]})
+			} else {
+				edits = append(edits, DiffSegment{Op: DiffDelete, Text: a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
@@ -77,6 +77,37 @@ func AuthMiddleware() gin.HandlerFunc {
 		c.Set("role", claims["role"].(string))
 		c.Set("status", claims["status"].(string))
 
+		// Kullanıcı adına açılmış oturumlar sonlandırıldığında token da geçersiz olur
+		if sessionID, ok := claims["impersonation_session_id"].(float64); ok {
+			if impersonationValidator == nil || !impersonationValidator(uint(sessionID)) {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"status": "error",
+					"error": gin.H{
+						"code":    "impersonation_ended",
+						"message": "Impersonation session has ended",
+					},
+				})
+				c.Abort()
+				return
+			}
+			c.Set("impersonator_id", uint(claims["impersonator_id"].(float64)))
+			c.Set("impersonation_session_id", uint(sessionID))
+		}
+
 		c.Next()
 	}
-} 
\ No newline at end of file
+}
+
+// OptionalAuthMiddleware authenticates the request like AuthMiddleware when an
+// Authorization header is sent and lets anonymous requests through otherwise.
+// It is used on public routes whose responses include data of the current user.
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	auth := AuthMiddleware()
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		auth(c)
+	}
+}
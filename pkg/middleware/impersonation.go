@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImpersonationValidator reports whether an impersonation session is still active
+type ImpersonationValidator func(sessionID uint) bool
+
+var impersonationValidator ImpersonationValidator
+
+// SetImpersonationValidator registers the check AuthMiddleware uses for impersonation
+// tokens. Without a validator impersonation tokens are rejected.
+func SetImpersonationValidator(validator ImpersonationValidator) {
+	impersonationValidator = validator
+}
+
+// BlockImpersonation rejects the request when it is made with an impersonation token.
+// It is used on destructive routes such as password change and account deletion.
+func BlockImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, impersonating := c.Get("impersonator_id"); impersonating {
+			c.JSON(http.StatusForbidden, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "impersonation_restricted",
+					"message": "This action is not allowed while impersonating a user",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
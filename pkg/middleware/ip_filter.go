@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IPBlocker reports whether requests from ip are blocked for the given scope
+type IPBlocker func(ip, scope string) bool
+
+var ipBlocker IPBlocker
+
+// SetIPBlocker registers the check used by IPFilter
+func SetIPBlocker(blocker IPBlocker) {
+	ipBlocker = blocker
+}
+
+// IPFilter rejects requests whose client IP is blocked for scope. It is used
+// globally with the "all" scope and on registration and login routes.
+func IPFilter(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ipBlocker != nil && ipBlocker(c.ClientIP(), scope) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "ip_blocked",
+					"message": "Requests from your network are not allowed",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ConfigureTrustedProxies makes c.ClientIP() read forwarding headers only when the
+// request comes from a proxy listed in TRUSTED_PROXIES (comma separated IPs or CIDRs).
+// Without it gin trusts every proxy and clients could spoof their IP.
+func ConfigureTrustedProxies(router *gin.Engine) error {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+
+	if headers := os.Getenv("CLIENT_IP_HEADERS"); headers != "" {
+		router.RemoteIPHeaders = nil
+		for _, header := range strings.Split(headers, ",") {
+			if header = strings.TrimSpace(header); header != "" {
+				router.RemoteIPHeaders = append(router.RemoteIPHeaders, header)
+			}
+		}
+	}
+
+	// nil, hiçbir proxy'ye güvenilmediği anlamına gelir
+	return router.SetTrustedProxies(proxies)
+}
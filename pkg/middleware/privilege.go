@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PrivilegeChecker reports whether a user has a reputation privilege and the
+// reputation the privilege requires
+type PrivilegeChecker func(userID uint, role, privilege string) (bool, int, error)
+
+var privilegeChecker PrivilegeChecker
+
+// SetPrivilegeChecker registers the check used by RequirePrivilege
+func SetPrivilegeChecker(checker PrivilegeChecker) {
+	privilegeChecker = checker
+}
+
+// RequirePrivilege rejects the request with insufficient_reputation unless the
+// user has earned privilege. It must run after AuthMiddleware.
+func RequirePrivilege(privilege string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if privilegeChecker == nil {
+			c.Next()
+			return
+		}
+
+		ok, required, err := privilegeChecker(c.GetUint("user_id"), c.GetString("role"), privilege)
+		if err != nil {
+			log.Printf("Failed to check privilege %s: %v", privilege, err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "internal_error",
+					"message": "Failed to check privileges",
+				},
+			})
+			c.Abort()
+			return
+		}
+		if !ok {
+			c.JSON(http.StatusForbidden, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":                "insufficient_reputation",
+					"message":             fmt.Sprintf("You need %d reputation for this action", required),
+					"privilege":           privilege,
+					"required_reputation": required,
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"github.com/anilsoylu/answer-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// RequestID assigns every request an ID, reusing a valid incoming X-Request-ID header
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if requestID == "" || len(requestID) > 64 {
+			generated, err := utils.GenerateRandomToken(16)
+			if err == nil {
+				requestID = generated
+			}
+		}
+
+		c.Set("request_id", requestID)
+		c.Writer.Header().Set(requestIDHeader, requestID)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WarningChecker reports whether a user still has to acknowledge a moderator warning
+type WarningChecker func(userID uint) bool
+
+var warningChecker WarningChecker
+
+// SetWarningChecker registers the check used by RequireAcknowledgedWarnings
+func SetWarningChecker(checker WarningChecker) {
+	warningChecker = checker
+}
+
+// RequireAcknowledgedWarnings rejects the request until the user has acknowledged
+// every warning. It is used on routes that create or change content.
+func RequireAcknowledgedWarnings() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if warningChecker != nil && warningChecker(c.GetUint("user_id")) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"status": "error",
+				"error": gin.H{
+					"code":    "warnings_unacknowledged",
+					"message": "You must acknowledge your warnings before continuing",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// GetEnvDuration reads a duration such as "720h" from the environment,
+// falling back to def when the variable is unset or invalid
+func GetEnvDuration(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid duration for %s (%q), using default %s", key, value, def)
+		return def
+	}
+	return d
+}
+
+// GetEnvInt reads an integer from the environment, falling back to def
+// when the variable is unset or invalid
+func GetEnvInt(key string, def int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid integer for %s (%q), using default %d", key, value, def)
+		return def
+	}
+	return n
+}
@@ -27,4 +27,23 @@ func ValidateToken(tokenString string) (*jwt.Token, error) {
 	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		return []byte(os.Getenv("JWT_SECRET")), nil
 	})
-} 
\ No newline at end of file
+}
+
+// GenerateImpersonationJWT issues a token for user that also carries the impersonating
+// admin and the impersonation session, so that restricted actions can be blocked
+func GenerateImpersonationJWT(user *models.User, impersonatorID, sessionID uint, expiresAt time.Time) (string, error) {
+	claims := jwt.MapClaims{
+		"user_id":                  user.ID,
+		"username":                 user.Username,
+		"email":                    user.Email,
+		"role":                     user.Role,
+		"status":                   user.Status,
+		"impersonator_id":          impersonatorID,
+		"impersonation_session_id": sessionID,
+		"exp":                      expiresAt.Unix(),
+		"iat":                      time.Now().Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+}
@@ -11,7 +11,7 @@ func GetValidationError(err error) string {
 		var errorMessages []string
 		for _, e := range validationErrors {
 			switch e.Tag() {
-			case "required":
+			case "required", "required_if":
 				errorMessages = append(errorMessages, e.Field()+" is required")
 			case "email":
 				errorMessages = append(errorMessages, e.Field()+" must be a valid email")